@@ -15,8 +15,15 @@ function setDefaultYear() {
   document.getElementById("year").value = (new Date()).getFullYear()
 }
 
+// Reads a lunar date written as MM/DD/YYYY. It doesn't go through Date, which
+// would roll a day such as the 30th of the 2nd month over into the next month.
+function parseLunarDate(text) {
+  const [month, day, year] = text.trim().split("/").map(s => parseInt(s, 10))
+  return {year: year, month: month, day: day}
+}
+
 function getLunarBirthdayForYear() {
-  const lunarBirthDate = new Date(document.getElementById("bd-lunar-birth-date").value)
+  const lunarBirthDate = parseLunarDate(document.getElementById("bd-lunar-birth-date").value)
   const isLeapMonth = document.getElementById("bd-is-leap-month").checked
   const year = parseInt(document.getElementById("year").value)
 
@@ -33,9 +40,10 @@ function getLunarBirthdayForYear() {
     }
   }
   reqBody = {
-    lunar_birth_date: lunarBirthDate.toISOString(),
+    lunar_birth_date: lunarBirthDate,
     is_leap_month: isLeapMonth,
     year: year,
+    calendar: document.getElementById("calendar").value,
   }
   req.open('POST', 'api/v1/lunar-birthday-for-year/')
   req.send(JSON.stringify(reqBody))
@@ -62,6 +70,16 @@ function getLunarBirthdayFromGregorian() {
   }
   reqBody = {
     solar_birth_date: gregBirthDate.toISOString(),
+    calendar: document.getElementById("calendar").value,
+  }
+  // With a time of birth, send the instant in this browser's time zone, so
+  // the birth date is reckoned in the calendar's own time zone.
+  const gregBirthTime = document.getElementById("greg-birth-time").value
+  if (gregBirthTime) {
+    const [hours, minutes] = gregBirthTime.split(":")
+    const birthTime = new Date(gregBirthDate)
+    birthTime.setHours(parseInt(hours), parseInt(minutes))
+    reqBody.solar_birth_time = birthTime.toISOString()
   }
   req.open('POST', 'api/v1/solar-to-lunar-birthday/')
   req.send(JSON.stringify(reqBody))
@@ -69,7 +87,8 @@ function getLunarBirthdayFromGregorian() {
 
 function getLunarBirthdayCalendar() {
   const personName = document.getElementById("person-name").value
-  const lunarBirthDate = new Date(document.getElementById("cal-lunar-birth-date").value)
+  const lunarBirthDateText = document.getElementById("cal-lunar-birth-date").value
+  const lunarBirthDate = parseLunarDate(lunarBirthDateText)
   const isLeapMonth = document.getElementById("cal-is-leap-month").checked
   const numYears = parseInt(document.getElementById("num-years").value)
   const notifications = JSON.parse(document.getElementById("notifications").value.trim())
@@ -86,12 +105,14 @@ function getLunarBirthdayCalendar() {
     }
   }
   reqBody = {
-    lunar_birth_date: lunarBirthDate.toISOString(),
+    lunar_birth_date: lunarBirthDate,
     is_leap_month: isLeapMonth,
-    last_year: lunarBirthDate.getFullYear() + numYears,
+    last_year: lunarBirthDate.year + numYears,
     title: `Birthday: ${personName}`,
-    description: `Birth Date: ${lunarBirthDate.toLocaleDateString()}`,
+    description: `Birth Date: ${lunarBirthDateText}`,
     notifications: notifications,
+    calendar: document.getElementById("calendar").value,
+    rokuyo: document.getElementById("cal-rokuyo").checked,
   }
   req.open('POST', 'api/v1/lunar-birthday-calendar/')
   req.send(JSON.stringify(reqBody))
@@ -27,15 +27,36 @@ type notification struct {
 // There's a really strange bug where VALARM isn't recognized by Google
 // Calendar. Even if you export a Google Calendar and re-import it to a fresh
 // Google Calendar it won't work.
-func generateLunarBirthdayCalendar(birthDate lunarsolar.LunarTime, lastYear int, title, description string, notifications []notification) *ics.Calendar {
+//
+// If rokuyo is true, each event's description ends with the rokuyō of its
+// date.
+func generateLunarBirthdayCalendar(lunarCal lunarsolar.Calendar, birthDate lunarsolar.LunarTime, lastYear int, title, description string, notifications []notification, rokuyo bool) (*ics.Calendar, error) {
 	cal := ics.NewCalendar()
 
-	for d := birthDate.Add(0); d.Time().Year() <= lastYear; d = d.AddDate(1, 0, 0) {
-		birthday := lunarsolar.LunarToSolar(d)
+	// Always count from the birth date, so a leap month or a 30th that's
+	// missing in one year doesn't carry over to the following years.
+	for years := 0; birthDate.Year()+years <= lastYear; years++ {
+		d, err := birthDate.AddYearsIn(lunarCal, years)
+		if err != nil {
+			return nil, err
+		}
+		birthday, err := lunarCal.ToSolar(d)
+		if err != nil {
+			return nil, err
+		}
+
+		desc := description
+		if rokuyo {
+			r, err := lunarsolar.Rokuyo(birthday)
+			if err != nil {
+				return nil, err
+			}
+			desc += fmt.Sprintf("\n六曜: %v", r)
+		}
 
 		ev := cal.AddEvent(fmt.Sprintf("%s-%v", title, birthday))
 		ev.SetSummary(title)
-		ev.SetDescription(description)
+		ev.SetDescription(desc)
 		ev.SetAllDayStartAt(birthday)
 		for _, notif := range notifications {
 			am := addVAlarm(ev)
@@ -43,7 +64,7 @@ func generateLunarBirthdayCalendar(birthDate lunarsolar.LunarTime, lastYear int,
 		}
 	}
 
-	return cal
+	return cal, nil
 }
 
 // Alarm configured to send a notification
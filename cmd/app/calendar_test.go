@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	ics "github.com/arran4/golang-ical"
 	"github.com/nlsun/lunar-solar-calendar/lunarsolar"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -45,9 +46,10 @@ func TestGenerateLunarBirthdayCalendar(t *testing.T) {
 		},
 	} {
 		t.Run(tc.scenario, func(t *testing.T) {
-			cal := generateLunarBirthdayCalendar(tc.lunarBirth, tc.lastYear, tc.title, tc.description, tc.notifications)
+			cal, err := generateLunarBirthdayCalendar(lunarsolar.ChineseCalendar(), tc.lunarBirth, tc.lastYear, tc.title, tc.description, tc.notifications, false)
+			require.NoError(t, err)
 
-			err := ioutil.WriteFile("calendar_test_output.ics", []byte(cal.Serialize()), 0644)
+			err = ioutil.WriteFile("calendar_test_output.ics", []byte(cal.Serialize()), 0644)
 			require.NoError(t, err)
 
 			b, err := ioutil.ReadFile(tc.scenario + ".ics")
@@ -111,3 +113,16 @@ func TestJsonDurationUnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateLunarBirthdayCalendarRokuyo(t *testing.T) {
+	// Lunar 2023-11-20 falls on 2024-01-01, and 2024-11-20 on 2024-12-20.
+	birth := lunarsolar.NewLunarDate(2023, 11, 20, false).At(0, 0, 0, 0, time.UTC)
+	cal, err := generateLunarBirthdayCalendar(lunarsolar.ChineseCalendar(), birth, 2024, "title", "description", nil, true)
+	require.NoError(t, err)
+
+	// The newline is escaped in the property value.
+	events := cal.Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, "description\\n六曜: 赤口", events[0].GetProperty(ics.ComponentPropertyDescription).Value)
+	assert.Equal(t, "description\\n六曜: 赤口", events[1].GetProperty(ics.ComponentPropertyDescription).Value)
+}
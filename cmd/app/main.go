@@ -7,6 +7,8 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/nlsun/lunar-solar-calendar/lunarsolar"
@@ -16,19 +18,73 @@ import (
 // Gregorian year.
 
 type lunarBirthdayForYearRequest struct {
-	LunarBirthDate time.Time `json:"lunar_birth_date"`
+	LunarBirthDate lunarDate `json:"lunar_birth_date"`
 	IsLeapMonth    bool      `json:"is_leap_month"`
 	Year           int       `json:"year"`
+	Calendar       string    `json:"calendar"`
+}
+
+// A lunar date in a request, given as text such as "2020-02-30" or
+// "1998-05*-02", as an object with year, month and day, or, as older clients
+// send it, as an RFC 3339 time whose year, month and day are the lunar date.
+// Its fields are kept as given, so the 30th of the 2nd month is not rolled
+// over as in a time.Time, and it is checked to exist in the calendar of the
+// request.
+type lunarDate struct {
+	Year   int  `json:"year"`
+	Month  int  `json:"month"`
+	Day    int  `json:"day"`
+	IsLeap bool `json:"-"`
+}
+
+func (d *lunarDate) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		type object lunarDate
+		return json.Unmarshal(b, (*object)(d))
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		year, month, day := t.Date()
+		*d = lunarDate{Year: year, Month: int(month), Day: day}
+		return nil
+	}
+	parts := strings.Split(s, "-")
+	if len(parts) != 3 {
+		return fmt.Errorf("lunar date %q is not in the form YYYY-MM-DD", s)
+	}
+	isLeap := strings.HasSuffix(parts[1], "*")
+	var fields [3]int
+	for i, part := range []string{parts[0], strings.TrimSuffix(parts[1], "*"), parts[2]} {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return fmt.Errorf("lunar date %q is not in the form YYYY-MM-DD", s)
+		}
+		fields[i] = n
+	}
+	*d = lunarDate{Year: fields[0], Month: fields[1], Day: fields[2], IsLeap: isLeap}
+	return nil
 }
 
 type lunarBirthdayForYearResponse struct {
 	Year  int `json:"year"`
 	Month int `json:"month"`
 	Day   int `json:"day"`
+	// Ages on the birthday.
+	ages
 }
 
 type solarToLunarBirthdayRequest struct {
 	SolarBirthDate time.Time `json:"solar_birth_date"`
+	// Instant of birth. If set, it's used instead of SolarBirthDate, and the
+	// birth date is its date in the calendar's time zone rather than in its
+	// own.
+	SolarBirthTime *time.Time `json:"solar_birth_time,omitempty"`
+	Calendar       string     `json:"calendar"`
+	// Date to give the ages on, today if not set.
+	At *time.Time `json:"at,omitempty"`
 }
 
 type solarToLunarBirthdayResponse struct {
@@ -36,15 +92,25 @@ type solarToLunarBirthdayResponse struct {
 	Month  int  `json:"month"`
 	Day    int  `json:"day"`
 	IsLeap bool `json:"is_leap"`
+	ages
+}
+
+type ages struct {
+	NominalAge int `json:"nominal_age"`
+	LunarAge   int `json:"lunar_age"`
+	WesternAge int `json:"western_age"`
 }
 
 type lunarBirthdayCalendarRequest struct {
-	LunarBirthDate time.Time      `json:"lunar_birth_date"`
+	LunarBirthDate lunarDate      `json:"lunar_birth_date"`
 	IsLeapMonth    bool           `json:"is_leap_month"`
 	LastYear       int            `json:"last_year"`
 	Title          string         `json:"title"`
 	Description    string         `json:"description"`
 	Notifications  []notification `json:"notifications"`
+	Calendar       string         `json:"calendar"`
+	// If true, annotate each event with its rokuyō.
+	Rokuyo bool `json:"rokuyo"`
 }
 
 type lunarBirthdayCalendarResponse struct {
@@ -114,13 +180,37 @@ func handlelunarBirthdayForYear(w http.ResponseWriter, req *http.Request) {
 
 	var reqBody lunarBirthdayForYearRequest
 	if err := json.Unmarshal(b, &reqBody); err != nil {
-		writeHttpErr(w, http.StatusInternalServerError)
+		writeHttpErr(w, http.StatusBadRequest)
 		log.Printf("%s: %s", string(b), err)
 		return
 	}
 
-	lunarBirthday := lunarsolar.NewLunarTime(reqBody.LunarBirthDate, reqBody.IsLeapMonth)
-	birthday, err := lunarBirthdayForYear(lunarBirthday, reqBody.Year)
+	cal, err := calendarByName(reqBody.Calendar)
+	if err != nil {
+		writeHttpErr(w, http.StatusBadRequest)
+		log.Print(err)
+		return
+	}
+	lunarBirthday := reqBody.LunarBirthDate.at(reqBody.IsLeapMonth)
+	if err := lunarBirthday.Date().ValidateIn(cal); err != nil {
+		writeHttpErr(w, http.StatusBadRequest)
+		log.Print(err)
+		return
+	}
+	birthday, err := lunarBirthdayForYear(cal, lunarBirthday, reqBody.Year)
+	if err != nil {
+		writeHttpErr(w, http.StatusBadRequest)
+		log.Print(err)
+		return
+	}
+	var a ages
+	a.NominalAge, err = lunarsolar.NominalAgeIn(cal, lunarBirthday, birthday)
+	if err == nil {
+		a.LunarAge, err = lunarsolar.LunarAgeIn(cal, lunarBirthday, birthday)
+	}
+	if err == nil {
+		a.WesternAge, err = lunarsolar.WesternAgeIn(cal, lunarBirthday, birthday)
+	}
 	if err != nil {
 		writeHttpErr(w, http.StatusBadRequest)
 		log.Print(err)
@@ -131,6 +221,7 @@ func handlelunarBirthdayForYear(w http.ResponseWriter, req *http.Request) {
 		Year:  birthday.Year(),
 		Month: int(birthday.Month()),
 		Day:   birthday.Day(),
+		ages:  a,
 	}
 	b, err = json.Marshal(resp)
 	if err != nil {
@@ -159,12 +250,40 @@ func handleSolarToLunarBirthday(w http.ResponseWriter, req *http.Request) {
 
 	var reqBody solarToLunarBirthdayRequest
 	if err := json.Unmarshal(b, &reqBody); err != nil {
-		writeHttpErr(w, http.StatusInternalServerError)
+		writeHttpErr(w, http.StatusBadRequest)
 		log.Printf("%s: %s", string(b), err)
 		return
 	}
 
-	birthday := lunarsolar.SolarToLunar(reqBody.SolarBirthDate)
+	cal, err := calendarByName(reqBody.Calendar)
+	if err != nil {
+		writeHttpErr(w, http.StatusBadRequest)
+		log.Print(err)
+		return
+	}
+	var birthday lunarsolar.LunarTime
+	if reqBody.SolarBirthTime != nil {
+		birthday, err = lunarsolar.ToLunarInstant(cal, *reqBody.SolarBirthTime)
+	} else {
+		birthday, err = cal.ToLunar(reqBody.SolarBirthDate)
+	}
+	if err != nil {
+		writeHttpErr(w, http.StatusBadRequest)
+		log.Print(err)
+		return
+	}
+	at := time.Now().In(birthday.Location())
+	if reqBody.At != nil {
+		at = *reqBody.At
+	}
+	var a ages
+	a.NominalAge, err = lunarsolar.NominalAgeIn(cal, birthday, at)
+	if err == nil {
+		a.LunarAge, err = lunarsolar.LunarAgeIn(cal, birthday, at)
+	}
+	if err == nil {
+		a.WesternAge, err = lunarsolar.WesternAgeIn(cal, birthday, at)
+	}
 	if err != nil {
 		writeHttpErr(w, http.StatusBadRequest)
 		log.Print(err)
@@ -172,10 +291,11 @@ func handleSolarToLunarBirthday(w http.ResponseWriter, req *http.Request) {
 	}
 
 	resp := solarToLunarBirthdayResponse{
-		Year:   birthday.Time().Year(),
-		Month:  int(birthday.Time().Month()),
-		Day:    birthday.Time().Day(),
+		Year:   birthday.Year(),
+		Month:  birthday.Month(),
+		Day:    birthday.Day(),
 		IsLeap: birthday.IsLeap(),
+		ages:   a,
 	}
 	b, err = json.Marshal(resp)
 	if err != nil {
@@ -204,13 +324,29 @@ func handlelunarBirthdayCalendar(w http.ResponseWriter, req *http.Request) {
 
 	var reqBody lunarBirthdayCalendarRequest
 	if err := json.Unmarshal(b, &reqBody); err != nil {
-		writeHttpErr(w, http.StatusInternalServerError)
+		writeHttpErr(w, http.StatusBadRequest)
 		log.Printf("%s: %s", string(b), err)
 		return
 	}
 
-	lunarBirthday := lunarsolar.NewLunarTime(reqBody.LunarBirthDate, reqBody.IsLeapMonth)
-	cal := generateLunarBirthdayCalendar(lunarBirthday, reqBody.LastYear, reqBody.Title, reqBody.Description, reqBody.Notifications)
+	lunarCal, err := calendarByName(reqBody.Calendar)
+	if err != nil {
+		writeHttpErr(w, http.StatusBadRequest)
+		log.Print(err)
+		return
+	}
+	lunarBirthday := reqBody.LunarBirthDate.at(reqBody.IsLeapMonth)
+	if err := lunarBirthday.Date().ValidateIn(lunarCal); err != nil {
+		writeHttpErr(w, http.StatusBadRequest)
+		log.Print(err)
+		return
+	}
+	cal, err := generateLunarBirthdayCalendar(lunarCal, lunarBirthday, reqBody.LastYear, reqBody.Title, reqBody.Description, reqBody.Notifications, reqBody.Rokuyo)
+	if err != nil {
+		writeHttpErr(w, http.StatusBadRequest)
+		log.Print(err)
+		return
+	}
 
 	resp := lunarBirthdayCalendarResponse{Calendar: cal.Serialize()}
 	b, err = json.Marshal(resp)
@@ -230,21 +366,36 @@ func handlelunarBirthdayCalendar(w http.ResponseWriter, req *http.Request) {
 //
 // If the birth date is a leap month, and the target year does not leap that
 // month, treat it as a non-leap-month birth date.
-func lunarBirthdayForYear(birthDate lunarsolar.LunarTime, solarYear int) (time.Time, error) {
-	lunarBirthYear := birthDate.Time().Year()
+//
+// If the birth date is the 30th, and that month has 29 days in the target
+// year, use the 29th.
+func lunarBirthdayForYear(cal lunarsolar.Calendar, birthDate lunarsolar.LunarTime, solarYear int) (time.Time, error) {
+	lunarBirthYear := birthDate.Year()
 	if lunarBirthYear > solarYear {
 		return time.Time{}, fmt.Errorf("birth year %d can't be greater than input year %d", lunarBirthYear, solarYear)
 	}
 
-	yearDiff := solarYear - lunarBirthYear
-	lunarBirthday := birthDate.AddDate(yearDiff, 0, 0)
+	// AddYearsIn handles both the leap month and the 30th.
+	lunarBirthday, err := birthDate.AddYearsIn(cal, solarYear-lunarBirthYear)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cal.ToSolar(lunarBirthday)
+}
+
+// Returns the lunar date at midnight UTC, in the leap month if isLeap or the
+// text of the date marks it as one.
+func (d lunarDate) at(isLeap bool) lunarsolar.LunarTime {
+	return lunarsolar.NewLunarDate(d.Year, d.Month, d.Day, d.IsLeap || isLeap).At(0, 0, 0, 0, time.UTC)
+}
 
-	// If born on a leap month, but this year that month is not a leap month,
-	// treat it as the normal month.
-	if birthDate.IsLeap() && !lunarsolar.IsLunarLeapMonthPossible(lunarBirthday) {
-		return lunarsolar.LunarToSolar(lunarBirthday.AsLeap(false)), nil
+// Returns the calendar with the name used in requests, the Chinese calendar
+// if the name is empty.
+func calendarByName(name string) (lunarsolar.Calendar, error) {
+	if name == "" {
+		return lunarsolar.ChineseCalendar(), nil
 	}
-	return lunarsolar.LunarToSolar(lunarBirthday), nil
+	return lunarsolar.CalendarByName(name)
 }
 
 func writeHttpErr(w http.ResponseWriter, code int) {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -48,9 +49,23 @@ func TestLunarBirthdayForYear(t *testing.T) {
 			targetSolarYear: 2010,
 			expected:        time.Date(2010, 6, 13, 0, 0, 0, 0, time.UTC),
 		},
+		{
+			scenario: "born 30th, target long month",
+			lunarBirth: lunarsolar.NewLunarDate(2020, 2, 30, false).
+				At(0, 0, 0, 0, time.UTC),
+			targetSolarYear: 2021,
+			expected:        time.Date(2021, 4, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			scenario: "born 30th, target short month",
+			lunarBirth: lunarsolar.NewLunarDate(2020, 2, 30, false).
+				At(0, 0, 0, 0, time.UTC),
+			targetSolarYear: 2022,
+			expected:        time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC),
+		},
 	} {
 		t.Run(tc.scenario, func(t *testing.T) {
-			solarBirthday, err := lunarBirthdayForYear(tc.lunarBirth, tc.targetSolarYear)
+			solarBirthday, err := lunarBirthdayForYear(lunarsolar.ChineseCalendar(), tc.lunarBirth, tc.targetSolarYear)
 			require.NoError(t, err)
 			assert.Equal(t, tc.expected, solarBirthday, fmt.Sprintf("%v\n%v", tc.expected, solarBirthday))
 		})
@@ -62,18 +77,91 @@ func TestLunarBirthdayForYearHTTP(t *testing.T) {
 	defer s.Close()
 
 	for _, tc := range []struct {
-		scenario string
-		request  map[string]interface{}
-		expected time.Time
+		scenario     string
+		request      map[string]interface{}
+		expected     time.Time
+		expectedAges ages
 	}{
 		{
 			scenario: "not leap year",
+			request: map[string]interface{}{
+				"lunar_birth_date": lunarDate{Year: 1958, Month: 11, Day: 6},
+				"is_leap_month":    false,
+				"year":             2020,
+			},
+			expected:     time.Date(2020, 12, 20, 0, 0, 0, 0, time.UTC),
+			expectedAges: ages{NominalAge: 63, LunarAge: 62, WesternAge: 62},
+		},
+		{
+			scenario: "korean leap month",
+			request: map[string]interface{}{
+				"lunar_birth_date": lunarDate{Year: 2012, Month: 3, Day: 11},
+				"is_leap_month":    true,
+				"year":             2013,
+				"calendar":         "korean",
+			},
+			expected:     time.Date(2013, 4, 20, 0, 0, 0, 0, time.UTC),
+			expectedAges: ages{NominalAge: 2, LunarAge: 1, WesternAge: 0},
+		},
+		{
+			scenario: "vietnamese new year",
+			request: map[string]interface{}{
+				"lunar_birth_date": lunarDate{Year: 1984, Month: 1, Day: 1},
+				"is_leap_month":    false,
+				"year":             1985,
+				"calendar":         "vietnamese",
+			},
+			expected:     time.Date(1985, 1, 21, 0, 0, 0, 0, time.UTC),
+			expectedAges: ages{NominalAge: 2, LunarAge: 1, WesternAge: 0},
+		},
+		{
+			scenario: "30th of a 30 day 2nd month",
+			request: map[string]interface{}{
+				"lunar_birth_date": lunarDate{Year: 2020, Month: 2, Day: 30},
+				"is_leap_month":    false,
+				"year":             2021,
+			},
+			expected:     time.Date(2021, 4, 11, 0, 0, 0, 0, time.UTC),
+			expectedAges: ages{NominalAge: 2, LunarAge: 1, WesternAge: 1},
+		},
+		{
+			scenario: "date as a time, as older clients send it",
 			request: map[string]interface{}{
 				"lunar_birth_date": time.Date(1958, 11, 6, 0, 0, 0, 0, time.UTC),
 				"is_leap_month":    false,
 				"year":             2020,
 			},
-			expected: time.Date(2020, 12, 20, 0, 0, 0, 0, time.UTC),
+			expected:     time.Date(2020, 12, 20, 0, 0, 0, 0, time.UTC),
+			expectedAges: ages{NominalAge: 63, LunarAge: 62, WesternAge: 62},
+		},
+		{
+			scenario: "date as text",
+			request: map[string]interface{}{
+				"lunar_birth_date": "2020-02-30",
+				"year":             2021,
+			},
+			expected:     time.Date(2021, 4, 11, 0, 0, 0, 0, time.UTC),
+			expectedAges: ages{NominalAge: 2, LunarAge: 1, WesternAge: 1},
+		},
+		{
+			scenario: "leap month as text",
+			request: map[string]interface{}{
+				"lunar_birth_date": "2012-03*-11",
+				"year":             2013,
+				"calendar":         "korean",
+			},
+			expected:     time.Date(2013, 4, 20, 0, 0, 0, 0, time.UTC),
+			expectedAges: ages{NominalAge: 2, LunarAge: 1, WesternAge: 0},
+		},
+		{
+			scenario: "ancestor born under the qing",
+			request: map[string]interface{}{
+				"lunar_birth_date": lunarDate{Year: 1850, Month: 1, Day: 1},
+				"is_leap_month":    false,
+				"year":             2020,
+			},
+			expected:     time.Date(2020, 1, 25, 0, 0, 0, 0, time.UTC),
+			expectedAges: ages{NominalAge: 171, LunarAge: 170, WesternAge: 169},
 		},
 	} {
 		t.Run(tc.scenario, func(t *testing.T) {
@@ -94,6 +182,157 @@ func TestLunarBirthdayForYearHTTP(t *testing.T) {
 
 			date := time.Date(respBody.Year, time.Month(respBody.Month), respBody.Day, 0.0, 0, 0, 0, time.UTC)
 			assert.Equal(t, tc.expected, date, fmt.Sprintf("%v\n%v", tc.expected, date))
+			assert.Equal(t, tc.expectedAges, respBody.ages)
+		})
+	}
+}
+
+func TestSolarToLunarBirthdayHTTP(t *testing.T) {
+	s := httptest.NewServer(mkHandler(""))
+	defer s.Close()
+
+	b, err := json.Marshal(map[string]interface{}{
+		"solar_birth_date": time.Date(1998, 6, 25, 0, 0, 0, 0, time.UTC),
+		"at":               time.Date(2010, 6, 13, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	reqURL := s.URL + "/api/v1/solar-to-lunar-birthday/"
+	resp, err := s.Client().Post(reqURL, "application/json", bytes.NewReader(b))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	b, err = ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var respBody solarToLunarBirthdayResponse
+	require.NoError(t, json.Unmarshal(b, &respBody))
+	assert.Equal(t, solarToLunarBirthdayResponse{
+		Year:   1998,
+		Month:  5,
+		Day:    2,
+		IsLeap: true,
+		ages:   ages{NominalAge: 13, LunarAge: 12, WesternAge: 11},
+	}, respBody)
+}
+
+// Born late on New Year's Eve in New York, already New Year's Day in China.
+func TestSolarToLunarBirthdayInstantHTTP(t *testing.T) {
+	s := httptest.NewServer(mkHandler(""))
+	defer s.Close()
+
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	b, err := json.Marshal(map[string]interface{}{
+		"solar_birth_time": time.Date(2023, 1, 21, 23, 0, 0, 0, ny),
+		"at":               time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	reqURL := s.URL + "/api/v1/solar-to-lunar-birthday/"
+	resp, err := s.Client().Post(reqURL, "application/json", bytes.NewReader(b))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	b, err = ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var respBody solarToLunarBirthdayResponse
+	require.NoError(t, json.Unmarshal(b, &respBody))
+	assert.Equal(t, solarToLunarBirthdayResponse{
+		Year:  2023,
+		Month: 1,
+		Day:   1,
+		ages:  ages{NominalAge: 2, LunarAge: 1, WesternAge: 1},
+	}, respBody)
+}
+
+func TestSolarToLunarBirthdayHTTPBeforeBirth(t *testing.T) {
+	s := httptest.NewServer(mkHandler(""))
+	defer s.Close()
+
+	b, err := json.Marshal(map[string]interface{}{
+		"solar_birth_date": time.Date(1998, 6, 25, 0, 0, 0, 0, time.UTC),
+		"at":               time.Date(1998, 6, 24, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	reqURL := s.URL + "/api/v1/solar-to-lunar-birthday/"
+	resp, err := s.Client().Post(reqURL, "application/json", bytes.NewReader(b))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestLunarBirthdayForYearHTTPInvalid(t *testing.T) {
+	s := httptest.NewServer(mkHandler(""))
+	defer s.Close()
+
+	for _, tc := range []struct {
+		scenario string
+		request  map[string]interface{}
+	}{
+		{
+			scenario: "no such leap month",
+			request: map[string]interface{}{
+				"lunar_birth_date": lunarDate{Year: 2019, Month: 3, Day: 1},
+				"is_leap_month":    true,
+				"year":             2020,
+			},
+		},
+		{
+			scenario: "day out of range",
+			request: map[string]interface{}{
+				"lunar_birth_date": lunarDate{Year: 2020, Month: 1, Day: 30},
+				"is_leap_month":    false,
+				"year":             2020,
+			},
+		},
+		{
+			scenario: "year out of range",
+			request: map[string]interface{}{
+				"lunar_birth_date": lunarDate{Year: 950, Month: 1, Day: 1},
+				"is_leap_month":    false,
+				"year":             2020,
+			},
+		},
+		{
+			scenario: "leap month only in the korean calendar",
+			request: map[string]interface{}{
+				"lunar_birth_date": lunarDate{Year: 2012, Month: 3, Day: 11},
+				"is_leap_month":    true,
+				"year":             2020,
+			},
+		},
+		{
+			scenario: "date not in the form YYYY-MM-DD",
+			request: map[string]interface{}{
+				"lunar_birth_date": "1958/11/06",
+				"is_leap_month":    false,
+				"year":             2020,
+			},
+		},
+		{
+			scenario: "unknown calendar",
+			request: map[string]interface{}{
+				"lunar_birth_date": lunarDate{Year: 1958, Month: 11, Day: 6},
+				"is_leap_month":    false,
+				"year":             2020,
+				"calendar":         "martian",
+			},
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			b, err := json.Marshal(tc.request)
+			require.NoError(t, err)
+
+			reqURL := s.URL + "/api/v1/lunar-birthday-for-year/"
+			resp, err := s.Client().Post(reqURL, "application/json", bytes.NewReader(b))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 		})
 	}
 }
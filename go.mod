@@ -5,6 +5,5 @@ go 1.15
 require (
 	github.com/arran4/golang-ical v0.0.0-20200913051209-9e0599124bb2
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/isee15/Lunar-Solar-Calendar-Converter/Go/lunarsolar v0.0.0-20201221054723-626479a03aa1
 	github.com/stretchr/testify v1.6.1
 )
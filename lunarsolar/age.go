@@ -0,0 +1,96 @@
+package lunarsolar
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrBeforeBirth is returned for an age asked for at a time before the birth.
+var ErrBeforeBirth = errors.New("lunarsolar: before birth")
+
+// NominalAge returns the nominal age (虚岁) on the date of at of someone born
+// on the lunar date of birth. It is 1 at birth and goes up by one at every
+// lunar new year. Only the year, month and day of at are used, as in
+// SolarToLunar. The ages are counted in the calendar of birth, see
+// NominalAgeIn.
+func NominalAge(birth LunarTime, at time.Time) (int, error) {
+	return NominalAgeIn(birth.Calendar(), birth, at)
+}
+
+// NominalAgeIn is like NominalAge but counts in the calendar c, in which the
+// date of birth is taken to be.
+func NominalAgeIn(c Calendar, birth LunarTime, at time.Time) (int, error) {
+	lunar, err := birthAndAt(c, birth, at)
+	if err != nil {
+		return 0, err
+	}
+	return lunar.date.Year - birth.date.Year + 1, nil
+}
+
+// LunarAge returns the number of lunar birthdays that someone born on the
+// lunar date of birth has had by the date of at. The birthday in each year is
+// found as by AddYears, so a leap month birthday falls in the regular month
+// in years without the leap month.
+func LunarAge(birth LunarTime, at time.Time) (int, error) {
+	return LunarAgeIn(birth.Calendar(), birth, at)
+}
+
+// LunarAgeIn is like LunarAge but counts in the calendar c, in which the date
+// of birth is taken to be.
+func LunarAgeIn(c Calendar, birth LunarTime, at time.Time) (int, error) {
+	lunar, err := birthAndAt(c, birth, at)
+	if err != nil {
+		return 0, err
+	}
+	age := lunar.date.Year - birth.date.Year
+	birthday, err := birth.AddYearsIn(c, age)
+	if err != nil {
+		return 0, err
+	}
+	if lunar.date.compare(birthday.date) < 0 {
+		age--
+	}
+	return age, nil
+}
+
+// WesternAge returns the number of Gregorian birthdays that someone born on
+// the lunar date of birth has had by the date of at. Someone born on
+// February 29 has their birthday on March 1 in common years.
+func WesternAge(birth LunarTime, at time.Time) (int, error) {
+	return WesternAgeIn(birth.Calendar(), birth, at)
+}
+
+// WesternAgeIn is like WesternAge but takes the date of birth to be in the
+// calendar c.
+func WesternAgeIn(c Calendar, birth LunarTime, at time.Time) (int, error) {
+	if _, err := birthAndAt(c, birth, at); err != nil {
+		return 0, err
+	}
+	solar, err := c.ToSolar(birth)
+	if err != nil {
+		return 0, err
+	}
+	year, month, d := at.Date()
+	age := year - solar.Year()
+	if month < solar.Month() || month == solar.Month() && d < solar.Day() {
+		age--
+	}
+	return age, nil
+}
+
+// birthAndAt checks the birth date and returns the lunar date of at in the
+// calendar c, which must not be before the birth.
+func birthAndAt(c Calendar, birth LunarTime, at time.Time) (LunarTime, error) {
+	if err := birth.date.ValidateIn(c); err != nil {
+		return LunarTime{}, err
+	}
+	lunar, err := c.ToLunar(at)
+	if err != nil {
+		return LunarTime{}, err
+	}
+	if lunar.date.compare(birth.date) < 0 {
+		return LunarTime{}, fmt.Errorf("%w: %v is before %v", ErrBeforeBirth, lunar.date, birth.date)
+	}
+	return lunar, nil
+}
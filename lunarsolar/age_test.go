@@ -0,0 +1,112 @@
+package lunarsolar
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAges(t *testing.T) {
+	for _, tc := range []struct {
+		scenario        string
+		birth           LunarTime
+		at              time.Time
+		expectedNominal int
+		expectedLunar   int
+		expectedWestern int
+	}{
+		{
+			scenario:        "day of birth",
+			birth:           NewLunarDate(2020, 12, 29, false).At(0, 0, 0, 0, time.UTC),
+			at:              time.Date(2021, 2, 10, 0, 0, 0, 0, time.UTC),
+			expectedNominal: 1,
+			expectedLunar:   0,
+			expectedWestern: 0,
+		},
+		{
+			scenario:        "next day is chinese new year",
+			birth:           NewLunarDate(2020, 12, 29, false).At(0, 0, 0, 0, time.UTC),
+			at:              time.Date(2021, 2, 12, 0, 0, 0, 0, time.UTC),
+			expectedNominal: 2,
+			expectedLunar:   0,
+			expectedWestern: 0,
+		},
+		{
+			scenario:        "after gregorian birthday, before lunar birthday",
+			birth:           NewLunarDate(1958, 11, 6, false).At(0, 0, 0, 0, time.UTC),
+			at:              time.Date(2020, 12, 19, 0, 0, 0, 0, time.UTC),
+			expectedNominal: 63,
+			expectedLunar:   61,
+			expectedWestern: 62,
+		},
+		{
+			scenario:        "on lunar birthday",
+			birth:           NewLunarDate(1958, 11, 6, false).At(0, 0, 0, 0, time.UTC),
+			at:              time.Date(2020, 12, 20, 0, 0, 0, 0, time.UTC),
+			expectedNominal: 63,
+			expectedLunar:   62,
+			expectedWestern: 62,
+		},
+		{
+			scenario:        "leap month birthday in a year without it",
+			birth:           NewLunarDate(1998, 5, 2, true).At(0, 0, 0, 0, time.UTC),
+			at:              time.Date(2010, 6, 13, 0, 0, 0, 0, time.UTC),
+			expectedNominal: 13,
+			expectedLunar:   12,
+			expectedWestern: 11,
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			nominal, err := NominalAge(tc.birth, tc.at)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedNominal, nominal)
+
+			lunar, err := LunarAge(tc.birth, tc.at)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedLunar, lunar)
+
+			western, err := WesternAge(tc.birth, tc.at)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedWestern, western)
+		})
+	}
+
+	birth := NewLunarDate(2020, 1, 1, false).At(0, 0, 0, 0, time.UTC)
+	_, err := NominalAge(birth, time.Date(2020, 1, 24, 0, 0, 0, 0, time.UTC))
+	assert.True(t, errors.Is(err, ErrBeforeBirth), err)
+	_, err = LunarAge(NewLunarDate(2020, 1, 30, false).At(0, 0, 0, 0, time.UTC), time.Now())
+	assert.True(t, errors.Is(err, ErrDayOutOfRange), err)
+}
+
+// A leap 3rd month of 2012 exists only in the Korean calendar.
+func TestAgesIn(t *testing.T) {
+	k := KoreanCalendar()
+	birth := NewLunarDate(2012, 3, 11, true).At(0, 0, 0, 0, time.UTC)
+	at := time.Date(2013, 4, 20, 0, 0, 0, 0, time.UTC)
+
+	nominal, err := NominalAgeIn(k, birth, at)
+	require.NoError(t, err)
+	assert.Equal(t, 2, nominal)
+	lunar, err := LunarAgeIn(k, birth, at)
+	require.NoError(t, err)
+	assert.Equal(t, 1, lunar)
+	lunar, err = LunarAgeIn(k, birth, at.AddDate(0, 0, -1))
+	require.NoError(t, err)
+	assert.Equal(t, 0, lunar)
+	western, err := WesternAgeIn(k, birth, at)
+	require.NoError(t, err)
+	assert.Equal(t, 0, western)
+
+	// The plain functions count in the calendar of the birth date.
+	_, err = LunarAge(birth, at)
+	assert.True(t, errors.Is(err, ErrNoSuchLeapMonth), err)
+	lunar, err = LunarAge(birth.AsCalendar(k), at)
+	require.NoError(t, err)
+	assert.Equal(t, 1, lunar)
+
+	_, err = WesternAgeIn(k, birth, time.Date(2012, 4, 30, 0, 0, 0, 0, time.UTC))
+	assert.True(t, errors.Is(err, ErrBeforeBirth), err)
+}
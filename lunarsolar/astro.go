@@ -0,0 +1,554 @@
+package lunarsolar
+
+import (
+	"math"
+	"time"
+)
+
+// The astronomy here follows Jean Meeus, Astronomical Algorithms, 2nd ed.
+// Instants are Julian Days: JD counts days of Universal Time and JDE days of
+// Terrestrial Time, the two differing by ΔT.
+
+const (
+	// j2000 is the JDE of 2000 January 1.5 TT.
+	j2000 = 2451545.0
+	// unixEpochJD is the JD of 1970 January 1 0h UT.
+	unixEpochJD = 2440587.5
+
+	synodicMonth  = 29.530588861
+	tropicalYear  = 365.242189
+	degree        = math.Pi / 180
+	arcsecond     = degree / 3600
+	secondsPerDay = 86400
+)
+
+// julianDay returns the JD of t.
+func julianDay(t time.Time) float64 {
+	return unixEpochJD + (float64(t.Unix())+float64(t.Nanosecond())/1e9)/secondsPerDay
+}
+
+// timeOfJulianDay returns the instant of the JD, in UTC, to the nearest
+// second.
+func timeOfJulianDay(jd float64) time.Time {
+	return time.Unix(int64(math.Round((jd-unixEpochJD)*secondsPerDay)), 0).UTC()
+}
+
+// jdeToJD converts from Terrestrial Time to Universal Time.
+func jdeToJD(jde float64) float64 {
+	return jde - deltaT(2000+(jde-j2000)/365.25)/secondsPerDay
+}
+
+// jdToJDE converts from Universal Time to Terrestrial Time.
+func jdToJDE(jd float64) float64 {
+	return jd + deltaT(2000+(jd-j2000)/365.25)/secondsPerDay
+}
+
+// deltaT returns TT - UT in seconds for a decimal year, using the polynomial
+// expressions of Espenak and Meeus. Far from the present these are estimates,
+// off by minutes or more.
+func deltaT(y float64) float64 {
+	switch {
+	case y < -500:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u
+	case y < 500:
+		return poly(y/100, 10583.6, -1014.41, 33.78311, -5.952053, -0.1798452, 0.022174192, 0.0090316521)
+	case y < 1600:
+		return poly((y-1000)/100, 1574.2, -556.01, 71.23472, 0.319781, -0.8503463, -0.005050998, 0.0083572073)
+	case y < 1700:
+		return poly(y-1600, 120, -0.9808, -0.01532, 1.0/7129)
+	case y < 1800:
+		return poly(y-1700, 8.83, 0.1603, -0.0059285, 0.00013336, -1.0/1174000)
+	case y < 1860:
+		return poly(y-1800, 13.72, -0.332447, 0.0068612, 0.0041116, -0.00037436, 0.0000121272, -0.0000001699, 0.000000000875)
+	case y < 1900:
+		return poly(y-1860, 7.62, 0.5737, -0.251754, 0.01680668, -0.0004473624, 1.0/233174)
+	case y < 1920:
+		return poly(y-1900, -2.79, 1.494119, -0.0598939, 0.0061966, -0.000197)
+	case y < 1941:
+		return poly(y-1920, 21.20, 0.84493, -0.076100, 0.0020936)
+	case y < 1961:
+		return poly(y-1950, 29.07, 0.407, -1.0/233, 1.0/2547)
+	case y < 1986:
+		return poly(y-1975, 45.45, 1.067, -1.0/260, -1.0/718)
+	case y < 2005:
+		return poly(y-2000, 63.86, 0.3345, -0.060374, 0.0017275, 0.000651814, 0.00002373599)
+	case y < 2050:
+		return poly(y-2000, 62.92, 0.32217, 0.005589)
+	case y < 2150:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u - 0.5628*(2150-y)
+	}
+	u := (y - 1820) / 100
+	return -20 + 32*u*u
+}
+
+// poly evaluates the polynomial with coefficients c, lowest power first.
+func poly(x float64, c ...float64) float64 {
+	var r float64
+	for i := len(c) - 1; i >= 0; i-- {
+		r = r*x + c[i]
+	}
+	return r
+}
+
+// normDegrees reduces an angle to [0, 360).
+func normDegrees(x float64) float64 {
+	x = math.Mod(x, 360)
+	if x < 0 {
+		x += 360
+	}
+	return x
+}
+
+// vsopTerm is one term A·cos(B + C·τ) of a VSOP87 series.
+type vsopTerm struct{ a, b, c float64 }
+
+// earthL and earthR are the heliocentric longitude and radius vector series
+// of the Earth from VSOP87, truncated as in Meeus, Astronomical Algorithms,
+// appendix III. Each element is the series multiplied by τ to that power.
+var earthL = [][]vsopTerm{
+	{
+		{175347046, 0, 0},
+		{3341656, 4.6692568, 6283.0758500},
+		{34894, 4.62610, 12566.15170},
+		{3497, 2.7441, 5753.3849},
+		{3418, 2.8289, 3.5231},
+		{3136, 3.6277, 77713.7715},
+		{2676, 4.4181, 7860.4194},
+		{2343, 6.1352, 3930.2097},
+		{1324, 0.7425, 11506.7698},
+		{1273, 2.0371, 529.6910},
+		{1199, 1.1096, 1577.3435},
+		{990, 5.233, 5884.927},
+		{902, 2.045, 26.298},
+		{857, 3.508, 398.149},
+		{780, 1.179, 5223.694},
+		{753, 2.533, 5507.553},
+		{505, 4.583, 18849.228},
+		{492, 4.205, 775.523},
+		{357, 2.920, 0.067},
+		{317, 5.849, 11790.629},
+		{284, 1.899, 796.298},
+		{271, 0.315, 10977.079},
+		{243, 0.345, 5486.778},
+		{206, 4.806, 2544.314},
+		{205, 1.869, 5573.143},
+		{202, 2.458, 6069.777},
+		{156, 0.833, 213.299},
+		{132, 3.411, 2942.463},
+		{126, 1.083, 20.775},
+		{115, 0.645, 0.980},
+		{103, 0.636, 4694.003},
+		{102, 0.976, 15720.839},
+		{102, 4.267, 7.114},
+		{99, 6.21, 2146.17},
+		{98, 0.68, 155.42},
+		{86, 5.98, 161000.69},
+		{85, 1.30, 6275.96},
+		{85, 3.67, 71430.70},
+		{80, 1.81, 17260.15},
+		{79, 3.04, 12036.46},
+		{75, 1.76, 5088.63},
+		{74, 3.50, 3154.69},
+		{74, 4.68, 801.82},
+		{70, 0.83, 9437.76},
+		{62, 3.98, 8827.39},
+		{61, 1.82, 7084.90},
+		{57, 2.78, 6286.60},
+		{56, 4.39, 14143.50},
+		{56, 3.47, 6279.55},
+		{52, 0.19, 12139.55},
+		{52, 1.33, 1748.02},
+		{51, 0.28, 5856.48},
+		{49, 0.49, 1194.45},
+		{41, 5.37, 8429.24},
+		{41, 2.40, 19651.05},
+		{39, 6.17, 10447.39},
+		{37, 6.04, 10213.29},
+		{37, 2.57, 1059.38},
+		{36, 1.71, 2352.87},
+		{36, 1.78, 6812.77},
+		{33, 0.59, 17789.85},
+		{30, 0.44, 83996.85},
+		{30, 2.74, 1349.87},
+		{25, 3.16, 4690.48},
+	},
+	{
+		{628331966747, 0, 0},
+		{206059, 2.678235, 6283.075850},
+		{4303, 2.6351, 12566.1517},
+		{425, 1.590, 3.523},
+		{119, 5.796, 26.298},
+		{109, 2.966, 1577.344},
+		{93, 2.59, 18849.23},
+		{72, 1.14, 529.69},
+		{68, 1.87, 398.15},
+		{67, 4.41, 5507.55},
+		{59, 2.89, 5223.69},
+		{56, 2.17, 155.42},
+		{45, 0.40, 796.30},
+		{36, 0.47, 775.52},
+		{29, 2.65, 7.11},
+		{21, 5.34, 0.98},
+		{19, 1.85, 5486.78},
+		{19, 4.97, 213.30},
+		{17, 2.99, 6275.96},
+		{16, 0.03, 2544.31},
+		{16, 1.43, 2146.17},
+		{15, 1.21, 10977.08},
+		{12, 2.83, 1748.02},
+		{12, 3.26, 5088.63},
+		{12, 5.27, 1194.45},
+		{12, 2.08, 4694.00},
+		{11, 0.77, 553.57},
+		{10, 1.30, 6286.60},
+		{10, 4.24, 1349.87},
+		{9, 2.70, 242.73},
+		{9, 5.64, 951.72},
+		{8, 5.30, 2352.87},
+		{6, 2.65, 9437.76},
+		{6, 4.67, 4690.48},
+	},
+	{
+		{52919, 0, 0},
+		{8720, 1.0721, 6283.0758},
+		{309, 0.867, 12566.152},
+		{27, 0.05, 3.52},
+		{16, 5.19, 26.30},
+		{16, 3.68, 155.42},
+		{10, 0.76, 18849.23},
+		{9, 2.06, 77713.77},
+		{7, 0.83, 775.52},
+		{5, 4.66, 1577.34},
+		{4, 1.03, 7.11},
+		{4, 3.44, 5573.14},
+		{3, 5.14, 796.30},
+		{3, 6.05, 5507.55},
+		{3, 1.19, 242.73},
+		{3, 6.12, 529.69},
+		{3, 0.31, 398.15},
+		{3, 2.28, 553.57},
+		{2, 4.38, 5223.69},
+		{2, 3.75, 0.98},
+	},
+	{
+		{289, 5.844, 6283.076},
+		{35, 0, 0},
+		{17, 5.49, 12566.15},
+		{3, 5.20, 155.42},
+		{1, 4.72, 3.52},
+		{1, 5.30, 18849.23},
+		{1, 5.97, 242.73},
+	},
+	{
+		{114, 3.142, 0},
+		{8, 4.13, 6283.08},
+		{1, 3.84, 12566.15},
+	},
+	{
+		{1, 3.14, 0},
+	},
+}
+
+var earthR = [][]vsopTerm{
+	{
+		{100013989, 0, 0},
+		{1670700, 3.0984635, 6283.0758500},
+		{13956, 3.05525, 12566.15170},
+		{3084, 5.1985, 77713.7715},
+		{1628, 1.1739, 5753.3849},
+		{1576, 2.8469, 7860.4194},
+		{925, 5.453, 11506.770},
+		{542, 4.564, 3930.210},
+		{472, 3.661, 5884.927},
+		{346, 0.964, 5507.553},
+		{329, 5.900, 5223.694},
+		{307, 0.299, 5573.143},
+		{243, 4.273, 11790.629},
+		{212, 5.847, 1577.344},
+		{186, 5.022, 10977.079},
+		{175, 3.012, 18849.228},
+		{110, 5.055, 5486.778},
+		{98, 0.89, 6069.78},
+		{86, 5.69, 15720.84},
+		{86, 1.27, 161000.69},
+		{65, 0.27, 17260.15},
+		{63, 0.92, 529.69},
+		{57, 2.01, 83996.85},
+		{56, 5.24, 71430.70},
+		{49, 3.25, 2544.31},
+		{47, 2.58, 775.52},
+		{45, 5.54, 9437.76},
+		{43, 6.01, 6275.96},
+		{39, 5.36, 4694.00},
+		{38, 2.39, 8827.39},
+		{37, 0.83, 19651.05},
+		{37, 4.90, 12139.55},
+		{36, 1.67, 12036.46},
+		{35, 1.84, 2942.46},
+		{33, 0.24, 7084.90},
+		{32, 0.18, 5088.63},
+		{32, 1.78, 398.15},
+		{28, 1.21, 6286.60},
+		{28, 1.90, 6279.55},
+		{26, 4.59, 10447.39},
+	},
+	{
+		{103019, 1.107490, 6283.075850},
+		{1721, 1.0644, 12566.1517},
+		{702, 3.142, 0},
+		{32, 1.02, 18849.23},
+		{31, 2.84, 5507.55},
+		{25, 1.32, 5223.69},
+		{18, 1.42, 1577.34},
+		{10, 5.91, 10977.08},
+		{9, 1.42, 6275.96},
+		{9, 0.27, 5486.78},
+	},
+	{
+		{4359, 5.7846, 6283.0758},
+		{124, 5.579, 12566.152},
+		{12, 3.14, 0},
+		{9, 3.63, 77713.77},
+		{6, 1.87, 5573.14},
+		{3, 5.47, 18849.23},
+	},
+	{
+		{145, 4.273, 6283.076},
+		{7, 3.92, 12566.15},
+	},
+	{
+		{4, 2.56, 6283.08},
+	},
+}
+
+func vsop(series [][]vsopTerm, tau float64) float64 {
+	var sum, p float64 = 0, 1
+	for _, terms := range series {
+		var s float64
+		for _, t := range terms {
+			s += t.a * math.Cos(t.b+t.c*tau)
+		}
+		sum += s * p
+		p *= tau
+	}
+	return sum / 1e8
+}
+
+// nutationInLongitude returns Δψ in radians, to about 0.5", for T in Julian
+// centuries from J2000.
+func nutationInLongitude(t float64) float64 {
+	omega := (125.04452 - 1934.136261*t) * degree
+	l := (280.4665 + 36000.7698*t) * degree
+	lp := (218.3165 + 481267.8813*t) * degree
+	return (-17.20*math.Sin(omega) - 1.32*math.Sin(2*l) - 0.23*math.Sin(2*lp) + 0.21*math.Sin(2*omega)) * arcsecond
+}
+
+// nutationInObliquity returns Δε in radians, to about 0.1", for T in Julian
+// centuries from J2000.
+func nutationInObliquity(t float64) float64 {
+	omega := (125.04452 - 1934.136261*t) * degree
+	l := (280.4665 + 36000.7698*t) * degree
+	lp := (218.3165 + 481267.8813*t) * degree
+	return (9.20*math.Cos(omega) + 0.57*math.Cos(2*l) + 0.10*math.Cos(2*lp) - 0.09*math.Cos(2*omega)) * arcsecond
+}
+
+// meanObliquity returns the mean obliquity of the ecliptic ε0 in radians, for
+// T in Julian centuries from J2000.
+func meanObliquity(t float64) float64 {
+	return (23+26.0/60)*degree + poly(t, 21.448, -46.8150, -0.00059, 0.001813)*arcsecond
+}
+
+// sunLongitude returns the apparent geocentric ecliptic longitude of the sun
+// in degrees, referred to the true equinox of date, at the JDE.
+func sunLongitude(jde float64) float64 {
+	tau := (jde - j2000) / 365250
+	l := vsop(earthL, tau) + math.Pi
+	r := vsop(earthR, tau)
+	// Conversion to the FK5 system, nutation and aberration.
+	l += -0.09033*arcsecond + nutationInLongitude(tau*10) - 20.4898*arcsecond/r
+	return normDegrees(l / degree)
+}
+
+// sunLongitudeJDE returns the JDE at which the apparent longitude of the sun
+// is lon degrees, searching from the estimate jde, which must be within a few
+// weeks.
+func sunLongitudeJDE(lon, jde float64) float64 {
+	for i := 0; i < 10; i++ {
+		d := math.Remainder(lon-sunLongitude(jde), 360)
+		step := d / 360 * tropicalYear
+		jde += step
+		if math.Abs(step) < 1e-7 {
+			break
+		}
+	}
+	return jde
+}
+
+// winterSolsticeJD returns the JD of the December solstice of the Gregorian
+// year, when the sun reaches longitude 270°.
+func winterSolsticeJD(year int) float64 {
+	est := float64(civilToJDN(year, time.December, 21))
+	return jdeToJD(sunLongitudeJDE(270, est))
+}
+
+// newMoonEpoch is the JDE of the mean new moon of 2000 January 6, from which
+// lunations are numbered.
+const newMoonEpoch = 2451550.09766
+
+// newMoonTerms, fullMoonTerms and quarterTerms are the periodic terms of
+// the phases, Meeus ch. 49, as coefficients of phaseArguments.
+var (
+	newMoonTerms = [...]float64{
+		-0.40720, 0.17241, 0.01608, 0.01039, 0.00739, -0.00514, 0.00208, -0.00111, -0.00057, 0.00056,
+		-0.00042, 0.00042, 0.00038, -0.00024, -0.00017, -0.00007, 0.00004, 0.00004, 0.00003, 0.00003,
+		-0.00003, 0.00003, -0.00002, -0.00002, 0.00002, 0, 0,
+	}
+	fullMoonTerms = [...]float64{
+		-0.40614, 0.17302, 0.01614, 0.01043, 0.00734, -0.00515, 0.00209, -0.00111, -0.00057, 0.00056,
+		-0.00042, 0.00042, 0.00038, -0.00024, -0.00017, -0.00007, 0.00004, 0.00004, 0.00003, 0.00003,
+		-0.00003, 0.00003, -0.00002, -0.00002, 0.00002, 0, 0,
+	}
+	quarterTerms = [...]float64{
+		-0.62801, 0.17172, 0.00862, 0.00804, 0.00454, -0.01183, 0.00204, -0.00180, -0.00070, 0.00027,
+		-0.00040, 0.00032, 0.00032, -0.00034, -0.00017, 0, 0.00002, 0.00003, 0.00003, 0.00004,
+		-0.00004, 0.00002, -0.00005, -0.00002, 0, 0.00004, -0.00028,
+	}
+)
+
+// planetaryArguments are the terms A + B·k of the additional corrections
+// for all phases, with their coefficients in millionths of a day.
+var planetaryArguments = [...][3]float64{
+	{299.77, 0.107408, 325},
+	{251.88, 0.016321, 165},
+	{251.83, 26.651886, 164},
+	{349.42, 36.412478, 126},
+	{84.66, 18.206239, 110},
+	{141.74, 53.303771, 62},
+	{207.14, 2.453732, 60},
+	{154.84, 7.306860, 56},
+	{34.52, 27.261239, 47},
+	{207.19, 0.121824, 42},
+	{291.34, 1.844379, 40},
+	{161.72, 24.198154, 37},
+	{239.56, 25.513099, 35},
+	{331.55, 3.592518, 23},
+}
+
+// newMoonJDE returns the JDE of the true new moon of lunation k, counted from
+// the new moon of 2000 January 6. It is accurate to well under a minute for
+// several centuries around the present.
+func newMoonJDE(k float64) float64 {
+	return phaseJDE(k)
+}
+
+// phaseJDE returns the JDE of the true phase of lunation k: a new moon if k
+// is a whole number, and the first quarter, full moon or last quarter if its
+// fraction is .25, .5 or .75.
+func phaseJDE(k float64) float64 {
+	t := k / 1236.85
+	t2, t3, t4 := t*t, t*t*t, t*t*t*t
+	jde := newMoonEpoch + synodicMonth*k + 0.00015437*t2 - 0.000000150*t3 + 0.00000000073*t4
+
+	e := 1 - 0.002516*t - 0.0000074*t2
+	m := (2.5534 + 29.10535670*k - 0.0000014*t2 - 0.00000011*t3) * degree
+	mp := (201.5643 + 385.81693528*k + 0.0107582*t2 + 0.00001238*t3 - 0.000000058*t4) * degree
+	f := (160.7108 + 390.67050284*k - 0.0016118*t2 - 0.00000227*t3 + 0.000000011*t4) * degree
+	om := (124.7746 - 1.56375588*k + 0.0020672*t2 + 0.00000215*t3) * degree
+
+	phaseArguments := [...]float64{
+		math.Sin(mp), e * math.Sin(m), math.Sin(2 * mp), math.Sin(2 * f), e * math.Sin(mp-m),
+		e * math.Sin(mp+m), e * e * math.Sin(2*m), math.Sin(mp - 2*f), math.Sin(mp + 2*f), e * math.Sin(2*mp+m),
+		math.Sin(3 * mp), e * math.Sin(m+2*f), e * math.Sin(m-2*f), e * math.Sin(2*mp-m), math.Sin(om),
+		math.Sin(mp + 2*m), math.Sin(2*mp - 2*f), math.Sin(3 * m), math.Sin(mp + m - 2*f), math.Sin(2*mp + 2*f),
+		math.Sin(mp + m + 2*f), math.Sin(mp - m + 2*f), math.Sin(mp - m - 2*f), math.Sin(3*mp + m), math.Sin(4 * mp),
+		math.Sin(mp - 2*m), e * e * math.Sin(mp+2*m),
+	}
+	terms := &newMoonTerms
+	frac := k - math.Floor(k)
+	switch {
+	case math.Abs(frac-0.5) < 0.01:
+		terms = &fullMoonTerms
+	case math.Abs(frac-0.25) < 0.01 || math.Abs(frac-0.75) < 0.01:
+		terms = &quarterTerms
+		w := 0.00306 - 0.00038*e*math.Cos(m) + 0.00026*math.Cos(mp) -
+			0.00002*math.Cos(mp-m) + 0.00002*math.Cos(mp+m) + 0.00002*math.Cos(2*f)
+		if frac < 0.5 {
+			jde += w
+		} else {
+			jde -= w
+		}
+	}
+	for i, c := range terms {
+		jde += c * phaseArguments[i]
+	}
+	return jde + planetaryCorrection(k, t2)
+}
+
+func planetaryCorrection(k, t2 float64) float64 {
+	var c float64
+	for i, p := range planetaryArguments {
+		a := p[0] + p[1]*k
+		if i == 0 {
+			a -= 0.009173 * t2
+		}
+		c += p[2] * 1e-6 * math.Sin(a*degree)
+	}
+	return c
+}
+
+// newMoonJD returns the JD of the new moon of lunation k.
+func newMoonJD(k int) float64 {
+	return jdeToJD(newMoonJDE(float64(k)))
+}
+
+// lunationBefore returns the number of the last lunation whose new moon is at
+// or before the JD.
+func lunationBefore(jd float64) int {
+	k := int(math.Floor((jd - newMoonEpoch) / synodicMonth))
+	for newMoonJD(k) > jd {
+		k--
+	}
+	for newMoonJD(k+1) <= jd {
+		k++
+	}
+	return k
+}
+
+// civilToJDN returns the Julian Day Number of the proleptic Gregorian date,
+// the day that begins at noon UT of that date.
+func civilToJDN(year int, month time.Month, day int) int {
+	a := (14 - int(month)) / 12
+	y := year + 4800 - a
+	m := int(month) + 12*a - 3
+	return day + (153*m+2)/5 + 365*y + floorDiv(y, 4) - floorDiv(y, 100) + floorDiv(y, 400) - 32045
+}
+
+// jdnToCivil returns the proleptic Gregorian date of the Julian Day Number.
+func jdnToCivil(jdn int) (year int, month time.Month, day int) {
+	a := jdn + 32044
+	b := floorDiv(4*a+3, 146097)
+	c := a - floorDiv(146097*b, 4)
+	d := floorDiv(4*c+3, 1461)
+	e := c - floorDiv(1461*d, 4)
+	m := (5*e + 2) / 153
+	day = e - (153*m+2)/5 + 1
+	month = time.Month(m + 3 - 12*(m/10))
+	year = 100*b + d - 4800 + m/10
+	return year, month, day
+}
+
+// localJDN returns the Julian Day Number of the civil date in effect at the
+// JD in a zone offset seconds east of UTC.
+func localJDN(jd float64, offset int) int {
+	return int(math.Floor(jd + 0.5 + float64(offset)/secondsPerDay))
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,394 @@
+package lunarsolar
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// AstronomicalCalendar computes the lunar calendar from the positions of the
+// sun and moon, instead of looking it up in a table. It follows the rules
+// the Chinese calendar has used since 1645:
+//
+//   - A month begins on the day, in the calendar's time zone, on which a new
+//     moon falls.
+//   - The month that contains the winter solstice is the 11th month.
+//   - If there are 13 months from one 11th month up to the next, the first of
+//     them that contains no major solar term is a leap month, and repeats the
+//     number of the month before it.
+//
+// The major solar terms (zhongqi) are the instants at which the apparent
+// ecliptic longitude of the sun reaches a multiple of 30°. Month m normally
+// contains the term at 30°·(m-2), so the 11th month contains the winter
+// solstice at 270°.
+//
+// New moons come from the method of Meeus, Astronomical Algorithms, chapter
+// 49, and the sun's longitude from a truncated VSOP87 theory, both good to
+// well under a minute for the centuries around the present. Further out the
+// uncertainty in ΔT, the drift of the Earth's rotation, dominates. A new moon
+// or solar term within a minute or two of midnight can fall on either day,
+// which is where computed calendars disagree with each other.
+type AstronomicalCalendar struct {
+	// Seconds east of UTC of the time zone in which days are reckoned.
+	offset int
+	zone   *time.Location
+	// If set, days before the JD meridianChange are reckoned in earlyZone,
+	// earlyOffset seconds east of UTC.
+	meridianChange float64
+	earlyOffset    int
+	earlyZone      *time.Location
+	// First and last lunar years the calendar computes.
+	minYear, maxYear int
+	// If set, the major terms are spaced evenly from the winter solstice
+	// instead of following the apparent longitude of the sun.
+	meanTerms bool
+
+	mu sync.Mutex
+	// Months of each sui, the period from one winter solstice to the next,
+	// keyed by the year of the solstice that ends it.
+	suis  map[int][]astroMonth
+	years map[int]astroYear
+}
+
+// AstronomicalMonth describes one month computed by AstronomicalCalendar.
+type AstronomicalMonth struct {
+	Month  int
+	IsLeap bool
+	// NewMoon is the instant of the new moon that begins the month.
+	NewMoon time.Time
+	// Start is midnight at the beginning of the first day of the month, in
+	// the calendar's time zone.
+	Start time.Time
+	Days  int
+	// MajorTerms are the instants of the major solar terms that fall within
+	// the month. A month usually has one, a leap month has none, and a month
+	// near the winter solstice can have two.
+	MajorTerms []time.Time
+}
+
+type astroMonth struct {
+	month  int
+	isLeap bool
+	// Julian Day Number of the first day.
+	start int
+	// JD of the new moon.
+	newMoon float64
+	// JDs of the major solar terms.
+	majorTerms []float64
+}
+
+type astroYear struct {
+	months []astroMonth
+	// Julian Day Number of the next new year.
+	end int
+}
+
+const (
+	astronomicalMinYear = 1000
+	astronomicalMaxYear = 3000
+)
+
+// NewAstronomicalCalendar returns a calendar that reckons days in the time
+// zone offset east of UTC. The Chinese calendar uses 8 hours.
+func NewAstronomicalCalendar(offset time.Duration) *AstronomicalCalendar {
+	return newAstronomicalCalendar(offset, astronomicalMinYear, astronomicalMaxYear, false)
+}
+
+func newAstronomicalCalendar(offset time.Duration, minYear, maxYear int, meanTerms bool) *AstronomicalCalendar {
+	seconds := int(offset / time.Second)
+	return &AstronomicalCalendar{
+		offset:    seconds,
+		zone:      time.FixedZone(zoneName(seconds), seconds),
+		minYear:   minYear,
+		maxYear:   maxYear,
+		meanTerms: meanTerms,
+		suis:      map[int][]astroMonth{},
+		years:     map[int]astroYear{},
+	}
+}
+
+func zoneName(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	switch {
+	case offset%3600 == 0:
+		return fmt.Sprintf("UTC%c%d", sign, offset/3600)
+	case offset%60 == 0:
+		return fmt.Sprintf("UTC%c%d:%02d", sign, offset/3600, offset/60%60)
+	}
+	return fmt.Sprintf("UTC%c%d:%02d:%02d", sign, offset/3600, offset/60%60, offset%60)
+}
+
+// MinYear returns the first lunar year the calendar computes.
+func (c *AstronomicalCalendar) MinYear() int {
+	return c.minYear
+}
+
+// MaxYear returns the last lunar year the calendar computes.
+func (c *AstronomicalCalendar) MaxYear() int {
+	return c.maxYear
+}
+
+// Location returns the time zone in which the calendar reckons days, the
+// later one if the calendar changed its meridian.
+func (c *AstronomicalCalendar) Location() *time.Location {
+	return c.zone
+}
+
+// Zone returns the time zone in which the calendar reckons days at the
+// instant t.
+func (c *AstronomicalCalendar) Zone(t time.Time) *time.Location {
+	return c.zoneAt(julianDay(t))
+}
+
+// ToLunar converts the solar date of t to a lunar date, keeping the time of
+// day and location. The result is in the calendar c, which its methods use.
+func (c *AstronomicalCalendar) ToLunar(t time.Time) (LunarTime, error) {
+	d, err := c.lunarOn(SolarToJDN(t))
+	if err != nil {
+		return LunarTime{}, err
+	}
+	return d.At(t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()).AsCalendar(c), nil
+}
+
+// lunarOn returns the lunar date that falls on the Julian Day Number.
+func (c *AstronomicalCalendar) lunarOn(jdn int) (LunarDate, error) {
+	year, month, d := jdnToCivil(jdn)
+
+	lunarYear := year
+	if year > c.MaxYear() || (year >= c.MinYear() && jdn < c.year(year).months[0].start) {
+		lunarYear--
+	}
+	if lunarYear < c.MinYear() || lunarYear > c.MaxYear() {
+		return LunarDate{}, fmt.Errorf("%w: %04d-%02d-%02d", ErrYearOutOfRange, year, month, d)
+	}
+	y := c.year(lunarYear)
+	if jdn >= y.end {
+		return LunarDate{}, fmt.Errorf("%w: %04d-%02d-%02d", ErrYearOutOfRange, year, month, d)
+	}
+
+	i := len(y.months) - 1
+	for y.months[i].start > jdn {
+		i--
+	}
+	m := y.months[i]
+	return NewLunarDate(lunarYear, m.month, jdn-m.start+1, m.isLeap), nil
+}
+
+// ToSolar converts the lunar date of t to a solar date, keeping the time of
+// day and location. It returns the same errors as LunarDate.Validate.
+func (c *AstronomicalCalendar) ToSolar(t LunarTime) (time.Time, error) {
+	jdn, err := c.jdnOf(t.date)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.solarOn(jdn), nil
+}
+
+// jdnOf returns the Julian Day Number of the lunar date.
+func (c *AstronomicalCalendar) jdnOf(d LunarDate) (int, error) {
+	m, err := c.validate(d)
+	if err != nil {
+		return 0, err
+	}
+	return m.start + d.Day - 1, nil
+}
+
+func (c *AstronomicalCalendar) validate(d LunarDate) (astroMonth, error) {
+	m, days, err := c.month(d.Year, d.Month, d.IsLeap)
+	if err != nil {
+		return astroMonth{}, fmt.Errorf("%w: %v", err, d)
+	}
+	if d.Day < 1 || d.Day > days {
+		return astroMonth{}, fmt.Errorf("%w: %v, month has %d days", ErrDayOutOfRange, d, days)
+	}
+	return m, nil
+}
+
+// LeapMonth returns the month that is repeated as a leap month in the lunar
+// year, or 0 if the year has no leap month.
+func (c *AstronomicalCalendar) LeapMonth(year int) (int, error) {
+	if year < c.MinYear() || year > c.MaxYear() {
+		return 0, fmt.Errorf("%w: %d", ErrYearOutOfRange, year)
+	}
+	for _, m := range c.year(year).months {
+		if m.isLeap {
+			return m.month, nil
+		}
+	}
+	return 0, nil
+}
+
+// DaysInMonth returns the number of days, 29 or 30, in the lunar month. If
+// isLeap is true it's the leap month that repeats month.
+func (c *AstronomicalCalendar) DaysInMonth(year, month int, isLeap bool) (int, error) {
+	_, days, err := c.month(year, month, isLeap)
+	if err != nil {
+		return 0, fmt.Errorf("%w: month %d of year %d", err, month, year)
+	}
+	return days, nil
+}
+
+// Months returns the months of the lunar year in order, along with the new
+// moons and major solar terms they were derived from.
+func (c *AstronomicalCalendar) Months(year int) ([]AstronomicalMonth, error) {
+	if year < c.MinYear() || year > c.MaxYear() {
+		return nil, fmt.Errorf("%w: %d", ErrYearOutOfRange, year)
+	}
+	y := c.year(year)
+	var months []AstronomicalMonth
+	for i, m := range y.months {
+		next := y.end
+		if i+1 < len(y.months) {
+			next = y.months[i+1].start
+		}
+		startYear, startMonth, startDay := jdnToCivil(m.start)
+		month := AstronomicalMonth{
+			Month:   m.month,
+			IsLeap:  m.isLeap,
+			NewMoon: timeOfJulianDay(m.newMoon).In(c.zoneAt(m.newMoon)),
+			Start:   time.Date(startYear, startMonth, startDay, 0, 0, 0, 0, c.zoneAt(m.newMoon)),
+			Days:    next - m.start,
+		}
+		for _, jd := range m.majorTerms {
+			month.MajorTerms = append(month.MajorTerms, timeOfJulianDay(jd).In(c.zoneAt(jd)))
+		}
+		months = append(months, month)
+	}
+	return months, nil
+}
+
+// month returns the month and its length, or an error without detail.
+func (c *AstronomicalCalendar) month(year, month int, isLeap bool) (astroMonth, int, error) {
+	if year < c.MinYear() || year > c.MaxYear() {
+		return astroMonth{}, 0, ErrYearOutOfRange
+	}
+	if month < 1 || month > 12 {
+		return astroMonth{}, 0, ErrMonthOutOfRange
+	}
+	y := c.year(year)
+	for i, m := range y.months {
+		if m.month != month || m.isLeap != isLeap {
+			continue
+		}
+		next := y.end
+		if i+1 < len(y.months) {
+			next = y.months[i+1].start
+		}
+		return m, next - m.start, nil
+	}
+	return astroMonth{}, 0, ErrNoSuchLeapMonth
+}
+
+// day returns the Julian Day Number of the date at the JD in the calendar's
+// time zone.
+func (c *AstronomicalCalendar) day(jd float64) int {
+	if jd < c.meridianChange {
+		return localJDN(jd, c.earlyOffset)
+	}
+	return localJDN(jd, c.offset)
+}
+
+// zoneAt returns the time zone in which the calendar reckons days at the JD.
+func (c *AstronomicalCalendar) zoneAt(jd float64) *time.Location {
+	if jd < c.meridianChange {
+		return c.earlyZone
+	}
+	return c.zone
+}
+
+// year returns the months of the lunar year, from the 1st month of the sui
+// ending in its winter solstice up to the 1st month of the next sui.
+func (c *AstronomicalCalendar) year(year int) astroYear {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if y, ok := c.years[year]; ok {
+		return y
+	}
+
+	this, next := c.sui(year), c.sui(year+1)
+	i, j := firstMonth(this), firstMonth(next)
+	y := astroYear{end: next[j].start}
+	y.months = append(y.months, this[i:]...)
+	y.months = append(y.months, next[:j]...)
+	c.years[year] = y
+	return y
+}
+
+// firstMonth returns the index of the 1st month in the months of a sui.
+func firstMonth(months []astroMonth) int {
+	for i, m := range months {
+		if m.month == 1 && !m.isLeap {
+			return i
+		}
+	}
+	panic("lunarsolar: sui without a 1st month")
+}
+
+// sui returns the months from the 11th month containing the winter solstice
+// of the year before, up to the 11th month containing the winter solstice of
+// the year. c.mu must be held.
+func (c *AstronomicalCalendar) sui(year int) []astroMonth {
+	if months, ok := c.suis[year]; ok {
+		return months
+	}
+
+	solstice := winterSolsticeJD(year - 1)
+	first, last := c.day(solstice), c.day(winterSolsticeJD(year))
+
+	// The new moons that begin the months. The 11th month begins on or
+	// before the day of the solstice, and the last new moon begins the 11th
+	// month of the next sui.
+	k := lunationBefore(solstice)
+	if c.day(newMoonJD(k+1)) == first {
+		k++
+	}
+	var months []astroMonth
+	for ; ; k++ {
+		jd := newMoonJD(k)
+		if c.day(jd) > last {
+			break
+		}
+		months = append(months, astroMonth{start: c.day(jd), newMoon: jd})
+	}
+	end := months[len(months)-1].start
+	months = months[:len(months)-1]
+
+	// The major terms from the winter solstice up to the next one, which
+	// falls in the next sui. Mean terms divide the tropical year evenly.
+	jde := jdToJDE(solstice)
+	for n := 0; n < 12; n++ {
+		jd := solstice + float64(n)*tropicalYear/12
+		if !c.meanTerms {
+			jd = jdeToJD(sunLongitudeJDE(normDegrees(270+30*float64(n)), jde+float64(n)*tropicalYear/12))
+		}
+		day := c.day(jd)
+		for i := range months {
+			if months[i].start <= day && (i+1 == len(months) || day < months[i+1].start) && day < end {
+				months[i].majorTerms = append(months[i].majorTerms, jd)
+			}
+		}
+	}
+
+	// Number the months, making the first one without a major term a leap
+	// month if the sui has 13.
+	needLeap := len(months) == 13
+	month := 11
+	for i := range months {
+		if i > 0 {
+			if needLeap && len(months[i].majorTerms) == 0 {
+				months[i].isLeap = true
+				needLeap = false
+			} else {
+				month = month%12 + 1
+			}
+		}
+		months[i].month = month
+	}
+
+	c.suis[year] = months
+	return months
+}
@@ -0,0 +1,154 @@
+package lunarsolar
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAstronomy(t *testing.T) {
+	for _, tc := range []struct {
+		scenario string
+		jd       float64
+		expected time.Time
+	}{
+		{
+			scenario: "winter solstice",
+			jd:       winterSolsticeJD(2020),
+			expected: time.Date(2020, 12, 21, 10, 2, 0, 0, time.UTC),
+		},
+		{
+			scenario: "new moon",
+			jd:       newMoonJD(lunationBefore(julianDay(time.Date(2020, 1, 25, 0, 0, 0, 0, time.UTC)))),
+			expected: time.Date(2020, 1, 24, 21, 42, 0, 0, time.UTC),
+		},
+		{
+			scenario: "equinox",
+			jd:       jdeToJD(sunLongitudeJDE(0, float64(civilToJDN(2021, 3, 20)))),
+			expected: time.Date(2021, 3, 20, 9, 37, 0, 0, time.UTC),
+		},
+		{
+			scenario: "new moon of a solar eclipse",
+			jd:       newMoonJD(lunationBefore(julianDay(time.Date(2017, 8, 22, 0, 0, 0, 0, time.UTC)))),
+			expected: time.Date(2017, 8, 21, 18, 30, 0, 0, time.UTC),
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			diff := timeOfJulianDay(tc.jd).Sub(tc.expected)
+			assert.True(t, math.Abs(diff.Minutes()) < 1, "%v", timeOfJulianDay(tc.jd))
+		})
+	}
+}
+
+func TestJDN(t *testing.T) {
+	assert.Equal(t, 2451545, civilToJDN(2000, 1, 1))
+	assert.Equal(t, 0, civilToJDN(-4713, 11, 24))
+	for jdn := civilToJDN(-1000, 1, 1); jdn < civilToJDN(3000, 1, 1); jdn += 97 {
+		year, month, day := jdnToCivil(jdn)
+		require.Equal(t, jdn, civilToJDN(year, month, day))
+		require.Equal(t, time.Date(year, month, day, 0, 0, 0, 0, time.UTC), time.Date(2000, 1, 1+jdn-2451545, 0, 0, 0, 0, time.UTC))
+	}
+}
+
+// The computed calendar should agree with the table in every year reckoned at
+// UTC+8, except where a new moon falls so close to midnight that the
+// difference between the methods decides the day.
+func TestAstronomicalCalendarMatchesTable(t *testing.T) {
+	cal := NewAstronomicalCalendar(8 * time.Hour)
+	nearMidnight := map[int]bool{
+		// New moon at 2057-09-28 23:59:57 UTC+8.
+		2057: true,
+		// New moon at 2089-09-04 23:57:36 UTC+8.
+		2089: true,
+		// New moon at 2097-08-07 23:59:40 UTC+8.
+		2097: true,
+	}
+	for year := 1929; year <= MaxYear; year++ {
+		if nearMidnight[year] {
+			continue
+		}
+		months, err := cal.Months(year)
+		require.NoError(t, err)
+
+		info, _ := yearInfo(year)
+		require.Equal(t, infoMonthCount(info), len(months), year)
+		start := JDNToSolar(newYearJDN(year, info), time.UTC)
+		for i, m := range months {
+			month, isLeap := infoMonthAt(info, i)
+			assert.Equal(t, month, m.Month, "%d %d", year, i)
+			assert.Equal(t, isLeap, m.IsLeap, "%d %d", year, i)
+			assert.Equal(t, start.Format("2006-01-02"), m.Start.Format("2006-01-02"), "%d %d", year, i)
+			assert.Equal(t, infoMonthDays(info, i), m.Days, "%d %d", year, i)
+			start = start.AddDate(0, 0, m.Days)
+		}
+	}
+}
+
+// In 2033 the month after the 7th has no major term, but there are only 12
+// months from the 11th month of 2032 to that of 2033, so it isn't a leap
+// month. The leap month falls after the 11th month of 2033 instead.
+func TestAstronomicalCalendar2033(t *testing.T) {
+	cal := NewAstronomicalCalendar(8 * time.Hour)
+
+	leapMonth, err := cal.LeapMonth(2033)
+	require.NoError(t, err)
+	assert.Equal(t, 11, leapMonth)
+
+	months, err := cal.Months(2033)
+	require.NoError(t, err)
+	var majorTerms []int
+	for _, m := range months {
+		majorTerms = append(majorTerms, len(m.MajorTerms))
+	}
+	assert.Equal(t, []int{1, 1, 1, 1, 1, 1, 1, 0, 1, 1, 1, 0, 2}, majorTerms)
+	assert.Equal(t, 8, months[7].Month)
+	assert.False(t, months[7].IsLeap)
+	assert.Equal(t, 11, months[11].Month)
+	assert.True(t, months[11].IsLeap)
+}
+
+func TestAstronomicalCalendarConversions(t *testing.T) {
+	cal := NewAstronomicalCalendar(8 * time.Hour)
+	for _, tc := range []struct {
+		scenario string
+		solar    time.Time
+		lunar    LunarDate
+	}{
+		{
+			scenario: "leap month",
+			solar:    time.Date(2020, 5, 23, 10, 0, 0, 0, time.UTC),
+			lunar:    NewLunarDate(2020, 4, 1, true),
+		},
+		{
+			scenario: "before the table",
+			solar:    time.Date(1800, 1, 25, 0, 0, 0, 0, time.UTC),
+			lunar:    NewLunarDate(1800, 1, 1, false),
+		},
+		{
+			scenario: "after the table",
+			solar:    time.Date(2101, 1, 29, 0, 0, 0, 0, time.UTC),
+			lunar:    NewLunarDate(2101, 1, 1, false),
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			lunar, err := cal.ToLunar(tc.solar)
+			require.NoError(t, err)
+			assert.Equal(t, tc.lunar, lunar.Date())
+
+			solar, err := cal.ToSolar(lunar)
+			require.NoError(t, err)
+			assert.Equal(t, tc.solar, solar)
+		})
+	}
+
+	_, err := cal.ToLunar(time.Date(cal.MaxYear()+1, 3, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, errors.Is(err, ErrYearOutOfRange), err)
+	_, err = cal.ToSolar(NewLunarDate(2019, 4, 1, true).At(0, 0, 0, 0, time.UTC))
+	assert.True(t, errors.Is(err, ErrNoSuchLeapMonth), err)
+	_, err = cal.ToSolar(NewLunarDate(2020, 1, 30, false).At(0, 0, 0, 0, time.UTC))
+	assert.True(t, errors.Is(err, ErrDayOutOfRange), err)
+}
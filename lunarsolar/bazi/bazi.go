@@ -0,0 +1,134 @@
+// Package bazi computes the Four Pillars of Destiny (八字, ba zi) of a birth:
+// the stem-branch pairs of its year, month, day and hour.
+//
+// The year pillar changes at Lichun and the month pillar at each minor solar
+// term, both taken as instants. The day and hour pillars follow the clock of
+// the birth, or optionally the local true solar time at a given longitude.
+package bazi
+
+import (
+	"time"
+
+	"github.com/nlsun/lunar-solar-calendar/lunarsolar"
+)
+
+// ZiHour is a convention for the Zi hour from 23:00 to 01:00, which spans
+// midnight.
+type ZiHour int
+
+const (
+	// ZiHourSplit changes the day pillar at midnight, and gives the late Zi
+	// hour from 23:00 the stem of the next day's Zi hour (夜子时).
+	ZiHourSplit ZiHour = iota
+	// ZiHourNextDay changes the day pillar at 23:00, so the whole Zi hour
+	// belongs to the next day (子初换日).
+	ZiHourNextDay
+	// ZiHourSameDay changes the day pillar at midnight, and gives the late Zi
+	// hour from 23:00 the same stem as the early Zi hour of its own day.
+	ZiHourSameDay
+)
+
+// Options configure how a chart is computed. The zero value uses the clock
+// time of the birth and ZiHourSplit.
+type Options struct {
+	// If true, the day and hour pillars use the local true solar time at
+	// Longitude rather than the clock time of the birth.
+	TrueSolarTime bool
+	// Longitude of the place of birth, in degrees east of Greenwich.
+	Longitude float64
+	ZiHour    ZiHour
+}
+
+// Chart is the four pillars of a birth.
+type Chart struct {
+	Year, Month, Day, Hour lunarsolar.GanZhi
+	// Time is the time of the birth used for the day and hour pillars, in a
+	// zone that reads true solar time if that was asked for.
+	Time time.Time
+}
+
+// New returns the chart of the birth.
+func New(birth time.Time, opts Options) (Chart, error) {
+	zodiac, err := lunarsolar.ZodiacOf(birth, lunarsolar.BoundaryLichun)
+	if err != nil {
+		return Chart{}, err
+	}
+
+	local := birth
+	if opts.TrueSolarTime {
+		local = lunarsolar.TrueSolarTime(birth, opts.Longitude)
+	}
+	year, month, d := local.Date()
+	day := lunarsolar.DayGanZhi(local)
+	hour := lunarsolar.HourGanZhi(local)
+	if local.Hour() == 23 {
+		switch opts.ZiHour {
+		case ZiHourNextDay:
+			day = lunarsolar.DayGanZhi(local.AddDate(0, 0, 1))
+		case ZiHourSameDay:
+			hour = lunarsolar.HourGanZhi(time.Date(year, month, d, 0, 0, 0, 0, local.Location()))
+		}
+	}
+
+	return Chart{
+		Year:  zodiac.GanZhi,
+		Month: lunarsolar.MonthGanZhi(birth),
+		Day:   day,
+		Hour:  hour,
+		Time:  local,
+	}, nil
+}
+
+// Pillars returns the year, month, day and hour pillars in that order.
+func (c Chart) Pillars() [4]lunarsolar.GanZhi {
+	return [4]lunarsolar.GanZhi{c.Year, c.Month, c.Day, c.Hour}
+}
+
+// String returns the pillars in Chinese separated by spaces, e.g.
+// "庚子 戊子 乙卯 丙子".
+func (c Chart) String() string {
+	return c.Year.String() + " " + c.Month.String() + " " + c.Day.String() + " " + c.Hour.String()
+}
+
+// HiddenStems returns the hidden stems (藏干) of the branch of each pillar.
+func (c Chart) HiddenStems() [4][]lunarsolar.Stem {
+	var stems [4][]lunarsolar.Stem
+	for i, p := range c.Pillars() {
+		stems[i] = HiddenStems(p.Branch())
+	}
+	return stems
+}
+
+// Elements returns the number of the eight stems and branches of the
+// pillars with each element, indexed by lunarsolar.Element. Hidden stems are
+// not counted.
+func (c Chart) Elements() [5]int {
+	var counts [5]int
+	for _, p := range c.Pillars() {
+		counts[p.Stem().Element()]++
+		counts[p.Branch().Element()]++
+	}
+	return counts
+}
+
+var hiddenStems = [12][]lunarsolar.Stem{
+	{lunarsolar.StemGui},
+	{lunarsolar.StemJi, lunarsolar.StemGui, lunarsolar.StemXin},
+	{lunarsolar.StemJia, lunarsolar.StemBing, lunarsolar.StemWu},
+	{lunarsolar.StemYi},
+	{lunarsolar.StemWu, lunarsolar.StemYi, lunarsolar.StemGui},
+	{lunarsolar.StemBing, lunarsolar.StemGeng, lunarsolar.StemWu},
+	{lunarsolar.StemDing, lunarsolar.StemJi},
+	{lunarsolar.StemJi, lunarsolar.StemDing, lunarsolar.StemYi},
+	{lunarsolar.StemGeng, lunarsolar.StemRen, lunarsolar.StemWu},
+	{lunarsolar.StemXin},
+	{lunarsolar.StemWu, lunarsolar.StemXin, lunarsolar.StemDing},
+	{lunarsolar.StemRen, lunarsolar.StemJia},
+}
+
+// HiddenStems returns the hidden stems (藏干) of the branch, the main stem
+// first.
+func HiddenStems(b lunarsolar.Branch) []lunarsolar.Stem {
+	stems := hiddenStems[(int(b)%12+12)%12]
+	return append([]lunarsolar.Stem(nil), stems...)
+}
@@ -0,0 +1,96 @@
+package bazi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nlsun/lunar-solar-calendar/lunarsolar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var cst = time.FixedZone("CST", 8*60*60)
+
+func TestNew(t *testing.T) {
+	for _, tc := range []struct {
+		scenario     string
+		birth        time.Time
+		opts         Options
+		expected     string
+		expectedTime string
+	}{
+		{
+			scenario:     "clock time",
+			birth:        time.Date(2000, 1, 1, 12, 0, 0, 0, cst),
+			expected:     "己卯 丙子 戊午 戊午",
+			expectedTime: "2000-01-01 12:00:00",
+		},
+		{
+			scenario:     "after lichun",
+			birth:        time.Date(2021, 2, 4, 8, 0, 0, 0, cst),
+			expected:     "辛丑 庚寅 癸未 丙辰",
+			expectedTime: "2021-02-04 08:00:00",
+		},
+		{
+			scenario:     "before lichun",
+			birth:        time.Date(2021, 2, 3, 8, 0, 0, 0, cst),
+			expected:     "庚子 己丑 壬午 甲辰",
+			expectedTime: "2021-02-03 08:00:00",
+		},
+		{
+			scenario:     "late zi hour, split",
+			birth:        time.Date(2000, 1, 1, 23, 30, 0, 0, cst),
+			expected:     "己卯 丙子 戊午 甲子",
+			expectedTime: "2000-01-01 23:30:00",
+		},
+		{
+			scenario:     "late zi hour, next day",
+			birth:        time.Date(2000, 1, 1, 23, 30, 0, 0, cst),
+			opts:         Options{ZiHour: ZiHourNextDay},
+			expected:     "己卯 丙子 己未 甲子",
+			expectedTime: "2000-01-01 23:30:00",
+		},
+		{
+			scenario:     "late zi hour, same day",
+			birth:        time.Date(2000, 1, 1, 23, 30, 0, 0, cst),
+			opts:         Options{ZiHour: ZiHourSameDay},
+			expected:     "己卯 丙子 戊午 壬子",
+			expectedTime: "2000-01-01 23:30:00",
+		},
+		{
+			// 00:30 CST is 22:17 true solar time of the previous day at 87.6°E
+			// in early January: 2h09m36s back for the longitude and 3m22s more
+			// for the equation of time.
+			scenario:     "true solar time",
+			birth:        time.Date(2000, 1, 2, 0, 30, 0, 0, cst),
+			opts:         Options{TrueSolarTime: true, Longitude: 87.6},
+			expected:     "己卯 丙子 戊午 癸亥",
+			expectedTime: "2000-01-01 22:17:02",
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			c, err := New(tc.birth, tc.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, c.String())
+			assert.Equal(t, tc.expectedTime, c.Time.Format("2006-01-02 15:04:05"))
+		})
+	}
+}
+
+func TestHiddenStemsAndElements(t *testing.T) {
+	c, err := New(time.Date(2000, 1, 1, 12, 0, 0, 0, cst), Options{})
+	require.NoError(t, err)
+
+	stems := c.HiddenStems()
+	assert.Equal(t, []lunarsolar.Stem{lunarsolar.StemYi}, stems[0])
+	assert.Equal(t, []lunarsolar.Stem{lunarsolar.StemGui}, stems[1])
+	assert.Equal(t, []lunarsolar.Stem{lunarsolar.StemDing, lunarsolar.StemJi}, stems[2])
+
+	// 己卯 丙子 戊午 戊午: Earth 己 戊 戊, Wood 卯, Fire 丙 午 午, Water 子.
+	expected := [5]int{}
+	expected[lunarsolar.Wood] = 1
+	expected[lunarsolar.Fire] = 3
+	expected[lunarsolar.Earth] = 3
+	expected[lunarsolar.Water] = 1
+	assert.Equal(t, expected, c.Elements())
+}
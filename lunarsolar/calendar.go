@@ -0,0 +1,210 @@
+package lunarsolar
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Calendar is a lunisolar calendar system. The package functions such as
+// SolarToLunar and LeapMonth use the Chinese calendar; a Calendar converts
+// dates in the same way under the rules of another system.
+type Calendar interface {
+	// ToLunar converts the solar date of t to a lunar date, keeping the time
+	// of day and location. Like SolarToLunar, it uses the date t has in its
+	// own location; see ToLunarInstant.
+	ToLunar(t time.Time) (LunarTime, error)
+	// ToSolar converts the lunar date of t to a solar date, keeping the time
+	// of day and location. It returns the same errors as
+	// LunarDate.ValidateIn.
+	ToSolar(t LunarTime) (time.Time, error)
+	// LeapMonth returns the month that is repeated as a leap month in the
+	// lunar year, or 0 if the year has no leap month.
+	LeapMonth(year int) (int, error)
+	// DaysInMonth returns the number of days, 29 or 30, in the lunar month.
+	// If isLeap is true it's the leap month that repeats month.
+	DaysInMonth(year, month int, isLeap bool) (int, error)
+	// MinYear returns the first lunar year the calendar covers.
+	MinYear() int
+	// MaxYear returns the last lunar year the calendar covers.
+	MaxYear() int
+	// Zone returns the time zone in which the calendar reckoned days at the
+	// instant t.
+	Zone(t time.Time) *time.Location
+}
+
+// ErrUnknownCalendar is returned by CalendarByName for a name that hasn't
+// been registered.
+var ErrUnknownCalendar = errors.New("lunarsolar: unknown calendar")
+
+type chineseCalendar struct{}
+
+// ChineseCalendar returns the Chinese calendar of the package functions. For
+// the years of the table it is looked up there. Before that it is the Qing
+// ShixianCalendar, and before 1645 the Ming DatongCalendar, so the calendar
+// runs from MinYear to MaxYear without a gap.
+func ChineseCalendar() Calendar {
+	return chineseCalendar{}
+}
+
+func (chineseCalendar) ToLunar(t time.Time) (LunarTime, error) {
+	return SolarToLunar(t)
+}
+
+func (chineseCalendar) ToSolar(t LunarTime) (time.Time, error) {
+	return LunarToSolar(t)
+}
+
+func (chineseCalendar) LeapMonth(year int) (int, error) {
+	return LeapMonth(year)
+}
+
+func (chineseCalendar) DaysInMonth(year, month int, isLeap bool) (int, error) {
+	return DaysInMonth(year, month, isLeap)
+}
+
+func (chineseCalendar) Zone(t time.Time) *time.Location {
+	return chinaZone(t)
+}
+
+func (chineseCalendar) MinYear() int {
+	return MinYear
+}
+
+func (chineseCalendar) MaxYear() int {
+	return MaxYear
+}
+
+var (
+	calendarsMu sync.RWMutex
+	calendars   = map[string]Calendar{}
+)
+
+func init() {
+	Register("chinese", ChineseCalendar())
+	Register("korean", KoreanCalendar())
+	Register("vietnamese", VietnameseCalendar())
+	Register("japanese", JapaneseCalendar())
+}
+
+// Register makes a calendar available by name to CalendarByName. The
+// package registers "chinese", "korean", "vietnamese" and "japanese". It
+// panics if the name is already registered or c is nil.
+func Register(name string, c Calendar) {
+	calendarsMu.Lock()
+	defer calendarsMu.Unlock()
+	if c == nil {
+		panic("lunarsolar: Register calendar is nil")
+	}
+	if _, dup := calendars[name]; dup {
+		panic("lunarsolar: Register called twice for calendar " + name)
+	}
+	calendars[name] = c
+}
+
+// CalendarByName returns the calendar registered under the name.
+func CalendarByName(name string) (Calendar, error) {
+	calendarsMu.RLock()
+	defer calendarsMu.RUnlock()
+	c, ok := calendars[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCalendar, name)
+	}
+	return c, nil
+}
+
+// Calendars returns the sorted names of the registered calendars.
+func Calendars() []string {
+	calendarsMu.RLock()
+	defer calendarsMu.RUnlock()
+	var names []string
+	for name := range calendars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ToLunarInstant converts the instant t to the lunar date and time of day at
+// which it fell in the calendar's zone, as SolarToLunarInstant does for the
+// Chinese calendar. The result is in that zone.
+func ToLunarInstant(c Calendar, t time.Time) (LunarTime, error) {
+	return c.ToLunar(t.In(c.Zone(t)))
+}
+
+// ValidateIn is like Validate but checks that the date exists in the
+// calendar c.
+func (d LunarDate) ValidateIn(c Calendar) error {
+	if d.Year < c.MinYear() || d.Year > c.MaxYear() {
+		return fmt.Errorf("%w: %v", ErrYearOutOfRange, d)
+	}
+	if d.Month < 1 || d.Month > 12 {
+		return fmt.Errorf("%w: %v", ErrMonthOutOfRange, d)
+	}
+	days, err := c.DaysInMonth(d.Year, d.Month, d.IsLeap)
+	if err != nil {
+		return err
+	}
+	if d.Day < 1 || d.Day > days {
+		return fmt.Errorf("%w: %v, month has %d days", ErrDayOutOfRange, d, days)
+	}
+	return nil
+}
+
+// AddYearsIn is like AddYears but counts years in the calendar c, which the
+// result is in. A leap month date falls in the regular month of a year that
+// doesn't repeat it, and the 30th becomes the 29th in a short month.
+func (t LunarTime) AddYearsIn(c Calendar, n int) (LunarTime, error) {
+	if err := t.date.ValidateIn(c); err != nil {
+		return LunarTime{}, err
+	}
+	year := t.date.Year + n
+	leap, err := c.LeapMonth(year)
+	if err != nil {
+		return LunarTime{}, err
+	}
+	d := NewLunarDate(year, t.date.Month, t.date.Day, t.date.IsLeap && leap == t.date.Month)
+	days, err := c.DaysInMonth(d.Year, d.Month, d.IsLeap)
+	if err != nil {
+		return LunarTime{}, err
+	}
+	if d.Day > days {
+		d.Day = days
+	}
+	t.date = d
+	return t.AsCalendar(c), nil
+}
+
+// Calendar returns the calendar of the date of t: the one that converted it,
+// or the Chinese calendar.
+func (t LunarTime) Calendar() Calendar {
+	if t.cal == nil {
+		return ChineseCalendar()
+	}
+	return t.cal
+}
+
+// AsCalendar returns t with its date taken to be in the calendar c, without
+// converting it. The methods of t count days, months and years in c.
+func (t LunarTime) AsCalendar(c Calendar) LunarTime {
+	if _, ok := c.(chineseCalendar); ok {
+		c = nil
+	}
+	t.cal = c
+	return t
+}
+
+// calendarName returns the name c is registered under.
+func calendarName(c Calendar) (string, bool) {
+	calendarsMu.RLock()
+	defer calendarsMu.RUnlock()
+	for name, r := range calendars {
+		if reflect.TypeOf(r) == reflect.TypeOf(c) && reflect.TypeOf(c).Comparable() && r == c {
+			return name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,120 @@
+package lunarsolar
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarByName(t *testing.T) {
+	assert.Equal(t, []string{"chinese", "japanese", "korean", "vietnamese"}, Calendars())
+
+	solar := time.Date(2012, 5, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		scenario string
+		name     string
+		expected LunarDate
+	}{
+		{
+			scenario: "chinese",
+			name:     "chinese",
+			expected: NewLunarDate(2012, 4, 11, false),
+		},
+		{
+			scenario: "korean",
+			name:     "korean",
+			expected: NewLunarDate(2012, 3, 11, true),
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			c, err := CalendarByName(tc.name)
+			require.NoError(t, err)
+			lunar, err := c.ToLunar(solar)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, lunar.Date())
+
+			back, err := c.ToSolar(lunar)
+			require.NoError(t, err)
+			assert.Equal(t, solar, back)
+		})
+	}
+
+	_, err := CalendarByName("mayan")
+	assert.True(t, errors.Is(err, ErrUnknownCalendar), err)
+	assert.Panics(t, func() { Register("chinese", ChineseCalendar()) })
+}
+
+func TestValidateIn(t *testing.T) {
+	for _, tc := range []struct {
+		scenario    string
+		date        LunarDate
+		expectedErr error
+	}{
+		{
+			scenario: "valid",
+			date:     NewLunarDate(2012, 3, 30, true),
+		},
+		{
+			scenario:    "no such leap month",
+			date:        NewLunarDate(2012, 4, 1, true),
+			expectedErr: ErrNoSuchLeapMonth,
+		},
+		{
+			scenario:    "day out of range",
+			date:        NewLunarDate(2012, 3, 31, true),
+			expectedErr: ErrDayOutOfRange,
+		},
+		{
+			scenario:    "month out of range",
+			date:        NewLunarDate(2012, 13, 1, false),
+			expectedErr: ErrMonthOutOfRange,
+		},
+		{
+			scenario:    "year out of range",
+			date:        NewLunarDate(3001, 1, 1, false),
+			expectedErr: ErrYearOutOfRange,
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			err := tc.date.ValidateIn(KoreanCalendar())
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, tc.expectedErr), err)
+		})
+	}
+}
+
+// AddYearsIn in the Chinese calendar agrees with AddYears.
+func TestAddYearsIn(t *testing.T) {
+	birth := NewLunarDate(2017, 6, 30, true).At(12, 0, 0, 0, time.UTC)
+	for n := 0; n < 20; n++ {
+		expected, err := birth.AddYears(n)
+		require.NoError(t, err)
+		actual, err := birth.AddYearsIn(ChineseCalendar(), n)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual, n)
+	}
+}
+
+// Half past midnight on Seollal in Seoul is still New Year's Eve in Beijing.
+func TestToLunarInstant(t *testing.T) {
+	instant := time.Date(2023, 1, 21, 15, 30, 0, 0, time.UTC)
+
+	lunar, err := ToLunarInstant(KoreanCalendar(), instant)
+	require.NoError(t, err)
+	assert.Equal(t, NewLunarDate(2023, 1, 1, false), lunar.Date())
+	hour, min, _ := lunar.Clock()
+	assert.Equal(t, []int{0, 30}, []int{hour, min})
+	assert.Equal(t, KoreanCalendar().Location(), lunar.Location())
+
+	lunar, err = ToLunarInstant(ChineseCalendar(), instant)
+	require.NoError(t, err)
+	assert.Equal(t, NewLunarDate(2022, 12, 30, false), lunar.Date())
+	hour, min, _ = lunar.Clock()
+	assert.Equal(t, []int{23, 30}, []int{hour, min})
+}
@@ -0,0 +1,87 @@
+package lunarsolar
+
+import "fmt"
+
+// LunarDate is a date in the lunar calendar. Its fields are stored as given
+// and never pass through the Gregorian calendar, so a date such as the 30th
+// day of a 29 day month is kept as is rather than being rolled over.
+type LunarDate struct {
+	Year int
+	// Month is the month number from 1 to 12. A leap month has the number of
+	// the month it repeats.
+	Month int
+	Day   int
+	// If true, this is the leap month that repeats Month.
+	IsLeap bool
+}
+
+// NewLunarDate returns the lunar date. The date is not checked to exist.
+func NewLunarDate(year, month, day int, isLeap bool) LunarDate {
+	return LunarDate{
+		Year:   year,
+		Month:  month,
+		Day:    day,
+		IsLeap: isLeap,
+	}
+}
+
+// ValidLunarDate returns the lunar date, or an error if it does not exist.
+// See LunarDate.Validate for the errors returned.
+func ValidLunarDate(year, month, day int, isLeap bool) (LunarDate, error) {
+	d := NewLunarDate(year, month, day, isLeap)
+	if err := d.Validate(); err != nil {
+		return LunarDate{}, err
+	}
+	return d, nil
+}
+
+// Validate checks that the date exists. The errors returned wrap
+// ErrYearOutOfRange, ErrMonthOutOfRange, ErrNoSuchLeapMonth or
+// ErrDayOutOfRange.
+func (d LunarDate) Validate() error {
+	if d.Year < tableMinYear {
+		return d.ValidateIn(historical(d.Year))
+	}
+	info, ok := yearInfo(d.Year)
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrYearOutOfRange, d)
+	}
+	if d.Month < 1 || d.Month > 12 {
+		return fmt.Errorf("%w: %v", ErrMonthOutOfRange, d)
+	}
+	if d.IsLeap && d.Month != infoLeapMonth(info) {
+		return fmt.Errorf("%w: %v", ErrNoSuchLeapMonth, d)
+	}
+	n := infoMonthDays(info, infoMonthIndex(info, d.Month, d.IsLeap))
+	if d.Day < 1 || d.Day > n {
+		return fmt.Errorf("%w: %v, month has %d days", ErrDayOutOfRange, d, n)
+	}
+	return nil
+}
+
+// String returns the date as year-month-day, with a "*" after the month if it
+// is a leap month, e.g. "1998-05*-02".
+func (d LunarDate) String() string {
+	leap := ""
+	if d.IsLeap {
+		leap = "*"
+	}
+	return fmt.Sprintf("%04d-%02d%s-%02d", d.Year, d.Month, leap, d.Day)
+}
+
+// compare orders dates by their fields, a leap month coming directly after
+// the month it repeats.
+func (d LunarDate) compare(e LunarDate) int {
+	switch {
+	case d.Year != e.Year:
+		return d.Year - e.Year
+	case d.Month != e.Month:
+		return d.Month - e.Month
+	case d.IsLeap != e.IsLeap:
+		if d.IsLeap {
+			return 1
+		}
+		return -1
+	}
+	return d.Day - e.Day
+}
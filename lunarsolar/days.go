@@ -0,0 +1,179 @@
+package lunarsolar
+
+import (
+	"fmt"
+	"time"
+)
+
+// LunarMonth is one month of a lunar year, as listed by MonthsOf.
+type LunarMonth struct {
+	Year   int
+	Month  int
+	IsLeap bool
+	// Start is the solar date of the 1st day of the month, at midnight UTC.
+	Start time.Time
+	Days  int
+}
+
+// Day is a solar date with its lunar date and the other details a calendar
+// view shows for it, as listed by DaysOf and Range, or DaysOfIn and RangeIn
+// for another calendar.
+type Day struct {
+	// Solar is midnight at the beginning of the day.
+	Solar time.Time
+	Lunar LunarDate
+	// GanZhi is the stem-branch of the day.
+	GanZhi GanZhi
+	// SolarTerm is the solar term that falls on the day in China, or in the
+	// zone of the calendar for RangeIn, or nil if there is none.
+	SolarTerm *SolarTermTime
+}
+
+// MonthsOf returns the months of the lunar year in order, with the leap month,
+// if any, after the month it repeats.
+func MonthsOf(year int) ([]LunarMonth, error) {
+	if year < tableMinYear {
+		computed, err := historical(year).Months(year)
+		if err != nil {
+			return nil, err
+		}
+		months := make([]LunarMonth, len(computed))
+		for i, m := range computed {
+			months[i] = LunarMonth{
+				Year:   year,
+				Month:  m.Month,
+				IsLeap: m.IsLeap,
+				Start:  JDNToSolar(SolarToJDN(m.Start), time.UTC),
+				Days:   m.Days,
+			}
+		}
+		return months, nil
+	}
+	info, ok := yearInfo(year)
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrYearOutOfRange, year)
+	}
+	jdn := newYearJDN(year, info)
+	months := make([]LunarMonth, infoMonthCount(info))
+	for i := range months {
+		month, isLeap := infoMonthAt(info, i)
+		months[i] = LunarMonth{
+			Year:   year,
+			Month:  month,
+			IsLeap: isLeap,
+			Start:  JDNToSolar(jdn, time.UTC),
+			Days:   infoMonthDays(info, i),
+		}
+		jdn += months[i].Days
+	}
+	return months, nil
+}
+
+// MonthsIn is like MonthsOf but lists the months of the year in the calendar
+// c.
+func MonthsIn(c Calendar, year int) ([]LunarMonth, error) {
+	if _, ok := c.(chineseCalendar); ok {
+		return MonthsOf(year)
+	}
+	leap, err := c.LeapMonth(year)
+	if err != nil {
+		return nil, err
+	}
+	jdn, err := jdnIn(c, NewLunarDate(year, 1, 1, false))
+	if err != nil {
+		return nil, err
+	}
+	var months []LunarMonth
+	for month := 1; month <= 12; month++ {
+		for _, isLeap := range []bool{false, true} {
+			if isLeap && month != leap {
+				continue
+			}
+			days, err := c.DaysInMonth(year, month, isLeap)
+			if err != nil {
+				return nil, err
+			}
+			months = append(months, LunarMonth{
+				Year:   year,
+				Month:  month,
+				IsLeap: isLeap,
+				Start:  JDNToSolar(jdn, time.UTC),
+				Days:   days,
+			})
+			jdn += days
+		}
+	}
+	return months, nil
+}
+
+// DaysOf returns the days of the lunar month in order, with Solar at midnight
+// UTC. If isLeap is true it's the leap month that repeats month.
+func DaysOf(year, month int, isLeap bool) ([]Day, error) {
+	return DaysOfIn(ChineseCalendar(), year, month, isLeap)
+}
+
+// DaysOfIn is like DaysOf but lists the days of the month in the calendar c.
+func DaysOfIn(c Calendar, year, month int, isLeap bool) ([]Day, error) {
+	days, err := c.DaysInMonth(year, month, isLeap)
+	if err != nil {
+		return nil, err
+	}
+	jdn, err := jdnIn(c, NewLunarDate(year, month, 1, isLeap))
+	if err != nil {
+		return nil, err
+	}
+	start := JDNToSolar(jdn, time.UTC)
+	return RangeIn(c, start, start.AddDate(0, 0, days-1))
+}
+
+// Range returns the days from the solar date of from to that of to, both
+// included, with Solar in the location of from. It returns no days if to is
+// before from, and ErrYearOutOfRange if a day does not fall within lunar
+// years MinYear to MaxYear. Only the year, month and day of from and to are
+// used.
+func Range(from, to time.Time) ([]Day, error) {
+	return RangeIn(ChineseCalendar(), from, to)
+}
+
+// RangeIn is like Range but gives the lunar dates in the calendar c, and the
+// solar terms on their dates in the zone of c.
+func RangeIn(c Calendar, from, to time.Time) ([]Day, error) {
+	first, last := SolarToJDN(from), SolarToJDN(to)
+	if last < first {
+		return nil, nil
+	}
+	// Check both ends before working out the solar terms of every year
+	// between them.
+	for _, jdn := range []int{first, last} {
+		if _, err := lunarIn(c, jdn); err != nil {
+			return nil, err
+		}
+	}
+	terms := solarTermsByJDN(c, from.Year(), to.Year())
+	days := make([]Day, 0, last-first+1)
+	for jdn := first; jdn <= last; jdn++ {
+		d, err := lunarIn(c, jdn)
+		if err != nil {
+			return nil, err
+		}
+		solar := JDNToSolar(jdn, from.Location())
+		day := Day{Solar: solar, Lunar: d, GanZhi: DayGanZhi(solar)}
+		if term, ok := terms[jdn]; ok {
+			day.SolarTerm = &term
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// solarTermsByJDN returns the solar terms of the Gregorian years, keyed by
+// the Julian Day Number of their date in the zone of the calendar c.
+func solarTermsByJDN(c Calendar, firstYear, lastYear int) map[int]SolarTermTime {
+	terms := map[int]SolarTermTime{}
+	for year := firstYear; year <= lastYear; year++ {
+		for _, term := range SolarTermsInYear(year) {
+			terms[SolarToJDN(term.Time.In(c.Zone(term.Time)))] = term
+		}
+	}
+	return terms
+}
@@ -0,0 +1,125 @@
+package lunarsolar
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonthsOf(t *testing.T) {
+	months, err := MonthsOf(2020)
+	require.NoError(t, err)
+	require.Len(t, months, 13)
+	assert.Equal(t, LunarMonth{Year: 2020, Month: 1, Start: time.Date(2020, 1, 25, 0, 0, 0, 0, time.UTC), Days: 29}, months[0])
+	assert.Equal(t, LunarMonth{Year: 2020, Month: 4, IsLeap: true, Start: time.Date(2020, 5, 23, 0, 0, 0, 0, time.UTC), Days: 29}, months[4])
+
+	total := 0
+	for i, m := range months {
+		days, err := DaysInMonth(m.Year, m.Month, m.IsLeap)
+		require.NoError(t, err)
+		assert.Equal(t, days, m.Days, i)
+		if i > 0 {
+			assert.Equal(t, months[i-1].Start.AddDate(0, 0, months[i-1].Days), m.Start, i)
+		}
+		total += m.Days
+	}
+	days, err := DaysInYear(2020)
+	require.NoError(t, err)
+	assert.Equal(t, days, total)
+
+	_, err = MonthsOf(MaxYear + 1)
+	assert.True(t, errors.Is(err, ErrYearOutOfRange), err)
+}
+
+func TestDaysOf(t *testing.T) {
+	days, err := DaysOf(2020, 4, true)
+	require.NoError(t, err)
+	require.Len(t, days, 29)
+	var terms []SolarTerm
+	for i, d := range days {
+		assert.Equal(t, NewLunarDate(2020, 4, i+1, true), d.Lunar)
+		assert.Equal(t, time.Date(2020, 5, 23+i, 0, 0, 0, 0, time.UTC), d.Solar)
+		if d.SolarTerm != nil {
+			terms = append(terms, d.SolarTerm.Term)
+			assert.Equal(t, "2020-06-05", d.Solar.Format("2006-01-02"))
+		}
+	}
+	// A leap month has no major term.
+	assert.Equal(t, []SolarTerm{Mangzhong}, terms)
+
+	_, err = DaysOf(2019, 4, true)
+	assert.True(t, errors.Is(err, ErrNoSuchLeapMonth), err)
+}
+
+func TestRange(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	days, err := Range(time.Date(2023, 1, 20, 15, 0, 0, 0, ny), time.Date(2023, 1, 23, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	var lunar []LunarDate
+	for _, d := range days {
+		lunar = append(lunar, d.Lunar)
+		assert.Equal(t, ny, d.Solar.Location())
+		assert.Equal(t, DayGanZhi(d.Solar), d.GanZhi)
+	}
+	assert.Equal(t, []LunarDate{
+		NewLunarDate(2022, 12, 29, false),
+		NewLunarDate(2022, 12, 30, false),
+		NewLunarDate(2023, 1, 1, false),
+		NewLunarDate(2023, 1, 2, false),
+	}, lunar)
+	require.NotNil(t, days[0].SolarTerm)
+	assert.Equal(t, Dahan, days[0].SolarTerm.Term)
+	assert.Nil(t, days[1].SolarTerm)
+
+	days, err = Range(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Empty(t, days)
+
+	_, err = Range(time.Date(2101, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2101, 2, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, errors.Is(err, ErrYearOutOfRange), err)
+
+	// Either end out of range fails before any solar terms are worked out.
+	_, err = Range(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, errors.Is(err, ErrYearOutOfRange), err)
+	_, err = Range(time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, errors.Is(err, ErrYearOutOfRange), err)
+}
+
+// Korea repeated the 3rd month of 2012, as Xiaoman fell after midnight there
+// on the day of the next new moon.
+func TestDaysOfInRangeIn(t *testing.T) {
+	k := KoreanCalendar()
+	days, err := DaysOfIn(k, 2012, 3, true)
+	require.NoError(t, err)
+	require.Len(t, days, 30)
+	assert.Equal(t, time.Date(2012, 4, 21, 0, 0, 0, 0, time.UTC), days[0].Solar)
+	assert.Equal(t, NewLunarDate(2012, 3, 1, true), days[0].Lunar)
+	assert.Equal(t, NewLunarDate(2012, 3, 30, true), days[29].Lunar)
+	// The leap month has Lixia but no major term.
+	for _, d := range days {
+		if d.SolarTerm != nil {
+			assert.False(t, d.SolarTerm.Term.IsMajor(), d.Solar)
+		}
+	}
+
+	days, err = RangeIn(k, time.Date(2012, 5, 20, 0, 0, 0, 0, time.UTC), time.Date(2012, 5, 21, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, days, 2)
+	assert.Nil(t, days[0].SolarTerm)
+	assert.Equal(t, NewLunarDate(2012, 4, 1, false), days[1].Lunar)
+	require.NotNil(t, days[1].SolarTerm)
+	assert.Equal(t, Xiaoman, days[1].SolarTerm.Term)
+
+	days, err = Range(time.Date(2012, 5, 20, 0, 0, 0, 0, time.UTC), time.Date(2012, 5, 21, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.NotNil(t, days[0].SolarTerm)
+	assert.Equal(t, Xiaoman, days[0].SolarTerm.Term)
+
+	_, err = DaysOfIn(k, 2012, 4, true)
+	assert.True(t, errors.Is(err, ErrNoSuchLeapMonth), err)
+}
@@ -0,0 +1,151 @@
+package lunarsolar
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// clockLayout is the time of day and zone in the text form of a LunarTime.
+const clockLayout = "15:04:05.999999999Z07:00"
+
+// IsZero reports whether t is the zero LunarTime.
+func (t LunarTime) IsZero() bool {
+	return t.date == LunarDate{} && t.clock == 0 && t.Location() == time.UTC && t.cal == nil
+}
+
+// MarshalText returns t as the date in LayoutNumeric form followed by the time
+// of day and zone offset, e.g. "1998-05*-02T15:04:05+08:00". Fractions of a
+// second are written as needed. The location is kept only as its offset.
+//
+// A date in a calendar other than the Chinese one is followed by the name the
+// calendar is registered under, e.g. "2012-03*-05T00:00:00+09:00[korean]".
+// It is an error if the calendar hasn't been registered.
+func (t LunarTime) MarshalText() ([]byte, error) {
+	jdn, err := t.JDN()
+	if err != nil {
+		if !t.IsZero() {
+			return nil, fmt.Errorf("lunarsolar: cannot marshal %v: %w", t.date, err)
+		}
+		jdn = SolarToJDN(time.Time{})
+	}
+	text := t.date.String() + "T" + t.solarOn(jdn).Format(clockLayout)
+	if t.cal != nil {
+		name, ok := calendarName(t.cal)
+		if !ok {
+			return nil, fmt.Errorf("lunarsolar: cannot marshal %v: calendar is not registered", t.date)
+		}
+		text += "[" + name + "]"
+	}
+	return []byte(text), nil
+}
+
+// UnmarshalText parses the form written by MarshalText. The date must exist
+// in its calendar, unless it is the zero LunarTime.
+func (t *LunarTime) UnmarshalText(text []byte) error {
+	s := string(text)
+	var c Calendar
+	if j := strings.LastIndexByte(s, '['); j >= 0 && strings.HasSuffix(s, "]") {
+		var err error
+		if c, err = CalendarByName(s[j+1 : len(s)-1]); err != nil {
+			return fmt.Errorf("%w: %q: %v", ErrParse, s, err)
+		}
+		s = s[:j]
+	}
+	i := strings.IndexByte(s, 'T')
+	if i < 0 {
+		return fmt.Errorf("%w: %q: missing time of day", ErrParse, s)
+	}
+	d, err := parse(LayoutNumeric, s[:i])
+	if err != nil {
+		return err
+	}
+	clock, err := time.Parse(clockLayout, s[i+1:])
+	if err != nil {
+		return fmt.Errorf("%w: %q: %v", ErrParse, s, err)
+	}
+	loc := clock.Location()
+	if _, offset := clock.Zone(); offset == 0 {
+		loc = time.UTC
+	}
+	lt := d.At(clock.Hour(), clock.Minute(), clock.Second(), clock.Nanosecond(), loc)
+	if c != nil {
+		lt = lt.AsCalendar(c)
+	}
+	if lt.IsZero() {
+		lt = LunarTime{}
+	} else if err := lt.Validate(); err != nil {
+		return err
+	}
+	*t = lt
+	return nil
+}
+
+// MarshalJSON returns t as a JSON string in the form of MarshalText.
+func (t LunarTime) MarshalJSON() ([]byte, error) {
+	text, err := t.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON parses a JSON string in the form of MarshalText. A JSON null
+// leaves t unchanged.
+func (t *LunarTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrParse, data, err)
+	}
+	return t.UnmarshalText([]byte(s))
+}
+
+// Value stores t as a string in the form of MarshalText.
+func (t LunarTime) Value() (driver.Value, error) {
+	text, err := t.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+// Scan reads a string or bytes in the form of MarshalText. NULL gives the
+// zero LunarTime.
+func (t *LunarTime) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*t = LunarTime{}
+		return nil
+	case string:
+		return t.UnmarshalText([]byte(src))
+	case []byte:
+		return t.UnmarshalText(src)
+	}
+	return fmt.Errorf("lunarsolar: cannot scan %T into LunarTime", src)
+}
+
+// MarshalText returns the date in LayoutNumeric form, e.g. "1998-05*-02".
+func (d LunarDate) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses a date in LayoutNumeric form. The date must exist,
+// unless it is the zero LunarDate.
+func (d *LunarDate) UnmarshalText(text []byte) error {
+	parsed, err := parse(LayoutNumeric, string(text))
+	if err != nil {
+		return err
+	}
+	if parsed != (LunarDate{}) {
+		if err := parsed.Validate(); err != nil {
+			return err
+		}
+	}
+	*d = parsed
+	return nil
+}
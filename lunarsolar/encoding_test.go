@@ -0,0 +1,100 @@
+package lunarsolar
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLunarTimeText(t *testing.T) {
+	cst := time.FixedZone("CST", 8*60*60)
+	for _, tc := range []struct {
+		scenario string
+		lunar    LunarTime
+		expected string
+	}{
+		{
+			scenario: "leap month",
+			lunar:    NewLunarDate(1998, 5, 2, true).At(15, 4, 5, 0, cst),
+			expected: "1998-05*-02T15:04:05+08:00",
+		},
+		{
+			scenario: "utc with fraction",
+			lunar:    NewLunarDate(2020, 2, 30, false).At(0, 0, 1, 500000000, time.UTC),
+			expected: "2020-02-30T00:00:01.5Z",
+		},
+		{
+			scenario: "zero",
+			lunar:    LunarTime{},
+			expected: "0000-00-00T00:00:00Z",
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			text, err := tc.lunar.MarshalText()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(text))
+
+			var lt LunarTime
+			require.NoError(t, lt.UnmarshalText(text))
+			assert.Equal(t, tc.lunar.Date(), lt.Date())
+			assert.True(t, tc.lunar.Equal(lt), lt)
+			d, err := tc.lunar.Sub(lt)
+			if !tc.lunar.IsZero() {
+				require.NoError(t, err)
+				assert.Zero(t, d)
+			}
+		})
+	}
+
+	_, err := NewLunarDate(1998, 4, 2, true).At(0, 0, 0, 0, time.UTC).MarshalText()
+	assert.True(t, errors.Is(err, ErrNoSuchLeapMonth), err)
+
+	var lt LunarTime
+	err = lt.UnmarshalText([]byte("1998-04*-02T00:00:00Z"))
+	assert.True(t, errors.Is(err, ErrNoSuchLeapMonth), err)
+	err = lt.UnmarshalText([]byte("1998-05*-02"))
+	assert.True(t, errors.Is(err, ErrParse), err)
+	err = lt.UnmarshalText([]byte("1998-05*-02T25:00:00Z"))
+	assert.True(t, errors.Is(err, ErrParse), err)
+}
+
+func TestLunarTimeJSON(t *testing.T) {
+	type birthday struct {
+		Name string
+		Date LunarTime
+		Day  LunarDate
+	}
+	b := birthday{
+		Name: "a",
+		Date: NewLunarDate(1998, 5, 2, true).At(8, 30, 0, 0, time.UTC),
+		Day:  NewLunarDate(1998, 5, 2, true),
+	}
+	data, err := json.Marshal(b)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Name":"a","Date":"1998-05*-02T08:30:00Z","Day":"1998-05*-02"}`, string(data))
+
+	var res birthday
+	require.NoError(t, json.Unmarshal(data, &res))
+	assert.Equal(t, b, res)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"Date":null}`), &res))
+	assert.Equal(t, b.Date, res.Date)
+}
+
+func TestLunarTimeSQL(t *testing.T) {
+	lt := NewLunarDate(1998, 5, 2, true).At(8, 30, 0, 0, time.UTC)
+	v, err := lt.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "1998-05*-02T08:30:00Z", v)
+
+	var res LunarTime
+	require.NoError(t, res.Scan([]byte("1998-05*-02T08:30:00Z")))
+	assert.Equal(t, lt, res)
+	require.NoError(t, res.Scan(nil))
+	assert.True(t, res.IsZero())
+	assert.Error(t, res.Scan(42))
+}
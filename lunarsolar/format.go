@@ -0,0 +1,226 @@
+package lunarsolar
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layouts for LunarTime.Format. As with time.Format, a layout shows how a
+// reference lunar date would be written: day 2 of month 1 of the year 2006,
+// a Bingxu (丙戌) year of the dog, at 15:04:05. The recognized elements are
+//
+//	2006      year                  二〇〇六  year in Chinese digits
+//	丙戌      stem-branch year      狗        zodiac animal
+//	1, 01     month                 正月      month name, e.g. 冬月, 腊月
+//	2, 02     day                   初二      day name, e.g. 廿一, 三十
+//	*         "*" in a leap month   闰, 閏    "闰" in a leap month
+//	15        hour                  04, 05    minute, second
+//
+// Any other text is copied as is.
+const (
+	LayoutNumeric       = "2006-01*-02"
+	LayoutChinese       = "二〇〇六年闰正月初二"
+	LayoutChineseGanZhi = "丙戌年闰正月初二"
+)
+
+const (
+	layoutNone = iota
+	layoutYear
+	layoutYearChinese
+	layoutYearGanZhi
+	layoutZodiac
+	layoutMonth
+	layoutZeroMonth
+	layoutMonthChinese
+	layoutLeap
+	layoutLeapChinese
+	layoutDay
+	layoutZeroDay
+	layoutDayChinese
+	layoutHour
+	layoutMinute
+	layoutSecond
+)
+
+// layoutElements are matched in order, so a longer element must come before
+// any element that is a prefix of it.
+var layoutElements = []struct {
+	text string
+	kind int
+}{
+	{"2006", layoutYear},
+	{"二〇〇六", layoutYearChinese},
+	{"丙戌", layoutYearGanZhi},
+	{"狗", layoutZodiac},
+	{"01", layoutZeroMonth},
+	{"02", layoutZeroDay},
+	{"04", layoutMinute},
+	{"05", layoutSecond},
+	{"15", layoutHour},
+	{"1", layoutMonth},
+	{"2", layoutDay},
+	{"正月", layoutMonthChinese},
+	{"初二", layoutDayChinese},
+	{"*", layoutLeap},
+	{"闰", layoutLeapChinese},
+	{"閏", layoutLeapChinese},
+}
+
+// nextLayoutElement splits the layout around its first element, returning
+// layoutNone if there is none.
+func nextLayoutElement(layout string) (prefix string, kind int, suffix string) {
+	for i := 0; i < len(layout); i++ {
+		for _, e := range layoutElements {
+			if strings.HasPrefix(layout[i:], e.text) {
+				return layout[:i], e.kind, layout[i+len(e.text):]
+			}
+		}
+	}
+	return layout, layoutNone, ""
+}
+
+// script holds the words for the named elements of a layout in one writing
+// system.
+type script struct {
+	leap    string
+	months  [12]string
+	animals [12]string
+	year    func(int) string
+	ganZhi  func(GanZhi) string
+	day     func(int) string
+}
+
+var (
+	simplified = script{
+		leap:    "闰",
+		months:  [12]string{"正月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "冬月", "腊月"},
+		animals: [12]string{"鼠", "牛", "虎", "兔", "龙", "蛇", "马", "羊", "猴", "鸡", "狗", "猪"},
+		year:    chineseYear,
+		ganZhi:  GanZhi.String,
+		day:     chineseDay,
+	}
+	traditional = script{
+		leap:    "閏",
+		months:  [12]string{"正月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "冬月", "臘月"},
+		animals: [12]string{"鼠", "牛", "虎", "兔", "龍", "蛇", "馬", "羊", "猴", "雞", "狗", "豬"},
+		year:    chineseYear,
+		ganZhi:  GanZhi.String,
+		day:     chineseDay,
+	}
+)
+
+var (
+	chineseDigits = [...]string{"〇", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+	dayTens       = [...]string{"初", "十", "廿", "三"}
+)
+
+// Format returns t formatted according to the layout, using Simplified
+// Chinese for the Chinese elements. See LayoutNumeric for the elements of a
+// layout.
+func (t LunarTime) Format(layout string) string {
+	return t.format(layout, &simplified)
+}
+
+// FormatTraditional is like Format but uses Traditional Chinese, e.g. "閏" and
+// "臘月".
+func (t LunarTime) FormatTraditional(layout string) string {
+	return t.format(layout, &traditional)
+}
+
+func (t LunarTime) format(layout string, sc *script) string {
+	d := t.date
+	var b strings.Builder
+	for layout != "" {
+		prefix, kind, suffix := nextLayoutElement(layout)
+		b.WriteString(prefix)
+		layout = suffix
+		switch kind {
+		case layoutYear:
+			b.WriteString(pad(d.Year, 4))
+		case layoutYearChinese:
+			b.WriteString(sc.year(d.Year))
+		case layoutYearGanZhi:
+			b.WriteString(sc.ganZhi(YearGanZhi(d.Year)))
+		case layoutZodiac:
+			b.WriteString(sc.animals[YearGanZhi(d.Year).Branch()])
+		case layoutMonth:
+			b.WriteString(strconv.Itoa(d.Month))
+		case layoutZeroMonth:
+			b.WriteString(pad(d.Month, 2))
+		case layoutMonthChinese:
+			b.WriteString(chineseMonth(d.Month, sc))
+		case layoutLeap:
+			if d.IsLeap {
+				b.WriteString("*")
+			}
+		case layoutLeapChinese:
+			if d.IsLeap {
+				b.WriteString(sc.leap)
+			}
+		case layoutDay:
+			b.WriteString(strconv.Itoa(d.Day))
+		case layoutZeroDay:
+			b.WriteString(pad(d.Day, 2))
+		case layoutDayChinese:
+			b.WriteString(sc.day(d.Day))
+		case layoutHour:
+			b.WriteString(pad(int(t.clock/time.Hour), 2))
+		case layoutMinute:
+			b.WriteString(pad(int(t.clock%time.Hour/time.Minute), 2))
+		case layoutSecond:
+			b.WriteString(pad(int(t.clock%time.Minute/time.Second), 2))
+		}
+	}
+	return b.String()
+}
+
+// pad formats n with leading zeros to at least width digits.
+func pad(n, width int) string {
+	s := strconv.Itoa(n)
+	if n < 0 {
+		return s
+	}
+	for len(s) < width {
+		s = "0" + s
+	}
+	return s
+}
+
+// chineseYear writes the year digit by digit, e.g. "一九九八".
+func chineseYear(year int) string {
+	var b strings.Builder
+	for _, c := range strconv.Itoa(year) {
+		if c < '0' || c > '9' {
+			b.WriteRune(c)
+			continue
+		}
+		b.WriteString(chineseDigits[c-'0'])
+	}
+	return b.String()
+}
+
+// chineseMonth returns the name of the month, or its number if it is out of
+// range.
+func chineseMonth(month int, sc *script) string {
+	if month < 1 || month > 12 {
+		return strconv.Itoa(month)
+	}
+	return sc.months[month-1]
+}
+
+// chineseDay returns the name of the day, e.g. "初一", "十五", "廿一" or "三十",
+// or its number if it is out of range.
+func chineseDay(day int) string {
+	switch {
+	case day < 1 || day > 30:
+		return strconv.Itoa(day)
+	case day == 10:
+		return "初十"
+	case day == 20:
+		return "二十"
+	case day == 30:
+		return "三十"
+	}
+	return dayTens[day/10] + chineseDigits[day%10]
+}
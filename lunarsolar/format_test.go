@@ -0,0 +1,74 @@
+package lunarsolar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	for _, tc := range []struct {
+		scenario    string
+		date        LunarTime
+		layout      string
+		expected    string
+		traditional string
+	}{
+		{
+			scenario:    "numeric leap month",
+			date:        NewLunarDate(2020, 4, 1, true).At(0, 0, 0, 0, time.UTC),
+			layout:      LayoutNumeric,
+			expected:    "2020-04*-01",
+			traditional: "2020-04*-01",
+		},
+		{
+			scenario:    "chinese leap month",
+			date:        NewLunarDate(2020, 4, 1, true).At(0, 0, 0, 0, time.UTC),
+			layout:      "闰正月初二",
+			expected:    "闰四月初一",
+			traditional: "閏四月初一",
+		},
+		{
+			scenario:    "chinese year",
+			date:        NewLunarDate(1958, 11, 6, false).At(0, 0, 0, 0, time.UTC),
+			layout:      LayoutChinese,
+			expected:    "一九五八年冬月初六",
+			traditional: "一九五八年冬月初六",
+		},
+		{
+			scenario:    "stem-branch year and zodiac",
+			date:        NewLunarDate(2024, 12, 21, false).At(0, 0, 0, 0, time.UTC),
+			layout:      "丙戌狗年闰正月初二",
+			expected:    "甲辰龙年腊月廿一",
+			traditional: "甲辰龍年臘月廿一",
+		},
+		{
+			scenario:    "unpadded with clock",
+			date:        NewLunarDate(1998, 5, 2, true).At(9, 8, 7, 0, time.UTC),
+			layout:      "2006/1*/2 15:04:05",
+			expected:    "1998/5*/2 09:08:07",
+			traditional: "1998/5*/2 09:08:07",
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.date.Format(tc.layout))
+			assert.Equal(t, tc.traditional, tc.date.FormatTraditional(tc.layout))
+		})
+	}
+}
+
+func TestChineseDay(t *testing.T) {
+	for day, expected := range map[int]string{
+		1:  "初一",
+		10: "初十",
+		11: "十一",
+		15: "十五",
+		20: "二十",
+		21: "廿一",
+		29: "廿九",
+		30: "三十",
+	} {
+		assert.Equal(t, expected, chineseDay(day))
+	}
+}
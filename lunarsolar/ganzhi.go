@@ -0,0 +1,192 @@
+package lunarsolar
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Stem is one of the ten heavenly stems (tiangan), from Jia to Gui.
+type Stem int
+
+const (
+	StemJia Stem = iota
+	StemYi
+	StemBing
+	StemDing
+	StemWu
+	StemJi
+	StemGeng
+	StemXin
+	StemRen
+	StemGui
+)
+
+// Branch is one of the twelve earthly branches (dizhi), from Zi to Hai.
+type Branch int
+
+const (
+	BranchZi Branch = iota
+	BranchChou
+	BranchYin
+	BranchMao
+	BranchChen
+	BranchSi
+	BranchWu
+	BranchWei
+	BranchShen
+	BranchYou
+	BranchXu
+	BranchHai
+)
+
+var (
+	stemChinese   = [...]string{"甲", "乙", "丙", "丁", "戊", "己", "庚", "辛", "壬", "癸"}
+	stemPinyin    = [...]string{"jiǎ", "yǐ", "bǐng", "dīng", "wù", "jǐ", "gēng", "xīn", "rén", "guǐ"}
+	branchChinese = [...]string{"子", "丑", "寅", "卯", "辰", "巳", "午", "未", "申", "酉", "戌", "亥"}
+	branchPinyin  = [...]string{"zǐ", "chǒu", "yín", "mǎo", "chén", "sì", "wǔ", "wèi", "shēn", "yǒu", "xū", "hài"}
+)
+
+// ErrInvalidGanZhi is returned for a stem and branch that are never paired,
+// one being odd and the other even.
+var ErrInvalidGanZhi = errors.New("lunarsolar: invalid stem-branch pair")
+
+// String returns the stem in Chinese, e.g. "庚".
+func (s Stem) String() string {
+	return stemChinese[mod(int(s), 10)]
+}
+
+// Pinyin returns the stem in pinyin with tone marks, e.g. "gēng".
+func (s Stem) Pinyin() string {
+	return stemPinyin[mod(int(s), 10)]
+}
+
+// String returns the branch in Chinese, e.g. "子".
+func (b Branch) String() string {
+	return branchChinese[mod(int(b), 12)]
+}
+
+// Pinyin returns the branch in pinyin with tone marks, e.g. "zǐ".
+func (b Branch) Pinyin() string {
+	return branchPinyin[mod(int(b), 12)]
+}
+
+// GanZhi is a position in the sexagenary cycle, from 0 for Jiazi (甲子) to 59
+// for Guihai (癸亥), pairing a stem and a branch.
+type GanZhi int
+
+// NewGanZhi returns the position in the cycle of the stem and branch.
+func NewGanZhi(stem Stem, branch Branch) (GanZhi, error) {
+	s, b := mod(int(stem), 10), mod(int(branch), 12)
+	if s%2 != b%2 {
+		return 0, fmt.Errorf("%w: %v%v", ErrInvalidGanZhi, Stem(s), Branch(b))
+	}
+	// The position that is s mod 10 and b mod 12.
+	return GanZhi((6*s - 5*b + 60) % 60), nil
+}
+
+// Stem returns the heavenly stem.
+func (g GanZhi) Stem() Stem {
+	return Stem(mod(int(g), 10))
+}
+
+// Branch returns the earthly branch.
+func (g GanZhi) Branch() Branch {
+	return Branch(mod(int(g), 12))
+}
+
+// String returns the stem and branch in Chinese, e.g. "庚子".
+func (g GanZhi) String() string {
+	return g.Stem().String() + g.Branch().String()
+}
+
+// Pinyin returns the stem and branch in pinyin with tone marks, e.g.
+// "gēngzǐ".
+func (g GanZhi) Pinyin() string {
+	return g.Stem().Pinyin() + g.Branch().Pinyin()
+}
+
+// YearGanZhi returns the stem-branch of the lunar year. 1984 is Jiazi.
+func YearGanZhi(year int) GanZhi {
+	return GanZhi(mod(year-1984, 60))
+}
+
+// MonthGanZhi returns the stem-branch of the solar month in which the instant
+// t falls. Solar months begin at the minor solar terms, the month of the
+// branch Yin at Lichun, so they are only roughly aligned with lunar months.
+func MonthGanZhi(t time.Time) GanZhi {
+	lon := sunLongitude(jdToJDE(julianDay(t)))
+	// Months since the one beginning at Lichun, 315°.
+	n := int(math.Floor(normDegrees(lon-315) / 30))
+	year := t.In(chinaZone(t)).Year()
+	if n >= 10 && t.In(chinaZone(t)).Month() <= time.February {
+		// The Zi and Chou months of the year that began at the previous
+		// Lichun.
+		year--
+	}
+	// The Yin month of 1984 is Bingyin, 2 in the cycle.
+	return GanZhi(mod((year-1984)*12+n+2, 60))
+}
+
+// DayGanZhi returns the stem-branch of the date of t. Only the year, month and
+// day of t are used.
+func DayGanZhi(t time.Time) GanZhi {
+	year, month, day := t.Date()
+	return GanZhi(mod(civilToJDN(year, month, day)+49, 60))
+}
+
+// HourGanZhi returns the stem-branch of the double hour (shichen) of t's wall
+// clock. The Zi hour runs from 23:00 to 01:00, and from 23:00 it belongs to
+// the next day, whose stem decides the stem of the hour.
+func HourGanZhi(t time.Time) GanZhi {
+	branch := (t.Hour() + 1) / 2 % 12
+	dayStem := DayGanZhi(t).Stem()
+	if t.Hour() == 23 {
+		dayStem = DayGanZhi(t.AddDate(0, 0, 1)).Stem()
+	}
+	// Jia and Ji days start with a Jiazi hour, Yi and Geng days with Bingzi,
+	// and so on.
+	stem := (int(dayStem)%5*2 + branch) % 10
+	g, _ := NewGanZhi(Stem(stem), Branch(branch))
+	return g
+}
+
+// YearGanZhi returns the stem-branch of the lunar year of t.
+func (t LunarTime) YearGanZhi() GanZhi {
+	return YearGanZhi(t.date.Year)
+}
+
+// MonthGanZhi returns the stem-branch of the solar month of t, see
+// MonthGanZhi. The date of t is converted in its calendar, as are those of
+// DayGanZhi and HourGanZhi.
+func (t LunarTime) MonthGanZhi() (GanZhi, error) {
+	solar, err := t.solar()
+	if err != nil {
+		return 0, err
+	}
+	return MonthGanZhi(solar), nil
+}
+
+// DayGanZhi returns the stem-branch of the day of t.
+func (t LunarTime) DayGanZhi() (GanZhi, error) {
+	solar, err := t.solar()
+	if err != nil {
+		return 0, err
+	}
+	return DayGanZhi(solar), nil
+}
+
+// HourGanZhi returns the stem-branch of the double hour of t, see HourGanZhi.
+func (t LunarTime) HourGanZhi() (GanZhi, error) {
+	solar, err := t.solar()
+	if err != nil {
+		return 0, err
+	}
+	return HourGanZhi(solar), nil
+}
+
+// mod returns a modulo b in [0, b).
+func mod(a, b int) int {
+	return ((a % b) + b) % b
+}
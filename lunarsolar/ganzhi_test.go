@@ -0,0 +1,135 @@
+package lunarsolar
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGanZhi(t *testing.T) {
+	for g := GanZhi(0); g < 60; g++ {
+		res, err := NewGanZhi(g.Stem(), g.Branch())
+		require.NoError(t, err)
+		assert.Equal(t, g, res)
+	}
+
+	_, err := NewGanZhi(StemJia, BranchChou)
+	assert.True(t, errors.Is(err, ErrInvalidGanZhi), err)
+}
+
+func TestGanZhiString(t *testing.T) {
+	g, err := NewGanZhi(StemGeng, BranchZi)
+	require.NoError(t, err)
+	assert.Equal(t, "庚子", g.String())
+	assert.Equal(t, "gēngzǐ", g.Pinyin())
+	assert.Equal(t, "甲子", GanZhi(0).String())
+	assert.Equal(t, "癸亥", GanZhi(59).String())
+}
+
+func TestYearGanZhi(t *testing.T) {
+	assert.Equal(t, "甲子", YearGanZhi(1984).String())
+	assert.Equal(t, "庚子", YearGanZhi(2020).String())
+	assert.Equal(t, "辛丑", YearGanZhi(2021).String())
+	assert.Equal(t, "庚子", YearGanZhi(1900).String())
+	assert.Equal(t, "戊戌", YearGanZhi(1898).String())
+
+	lunar, err := SolarToLunar(time.Date(2020, 1, 24, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, "己亥", lunar.YearGanZhi().String())
+}
+
+func TestMonthGanZhi(t *testing.T) {
+	for _, tc := range []struct {
+		scenario string
+		time     time.Time
+		expected string
+	}{
+		{
+			scenario: "before lichun",
+			time:     time.Date(2021, 2, 3, 22, 0, 0, 0, chinaStandardTime),
+			expected: "己丑",
+		},
+		{
+			scenario: "after lichun",
+			time:     time.Date(2021, 2, 4, 0, 0, 0, 0, chinaStandardTime),
+			expected: "庚寅",
+		},
+		{
+			scenario: "zi month in december",
+			time:     time.Date(2020, 12, 31, 0, 0, 0, 0, chinaStandardTime),
+			expected: "戊子",
+		},
+		{
+			scenario: "chou month in january",
+			time:     time.Date(2021, 1, 10, 0, 0, 0, 0, chinaStandardTime),
+			expected: "己丑",
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MonthGanZhi(tc.time).String())
+		})
+	}
+}
+
+func TestDayHourGanZhi(t *testing.T) {
+	for _, tc := range []struct {
+		scenario     string
+		time         time.Time
+		expectedDay  string
+		expectedHour string
+	}{
+		{
+			scenario:     "noon",
+			time:         time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC),
+			expectedDay:  "戊午",
+			expectedHour: "戊午",
+		},
+		{
+			scenario:     "early zi hour",
+			time:         time.Date(2000, 1, 1, 0, 30, 0, 0, time.UTC),
+			expectedDay:  "戊午",
+			expectedHour: "壬子",
+		},
+		{
+			scenario:     "late zi hour belongs to the next day",
+			time:         time.Date(2000, 1, 1, 23, 30, 0, 0, time.UTC),
+			expectedDay:  "戊午",
+			expectedHour: "甲子",
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			assert.Equal(t, tc.expectedDay, DayGanZhi(tc.time).String())
+			assert.Equal(t, tc.expectedHour, HourGanZhi(tc.time).String())
+		})
+	}
+
+	lunar, err := SolarToLunar(time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	day, err := lunar.DayGanZhi()
+	require.NoError(t, err)
+	assert.Equal(t, "戊午", day.String())
+	hour, err := lunar.HourGanZhi()
+	require.NoError(t, err)
+	assert.Equal(t, "戊午", hour.String())
+	month, err := lunar.MonthGanZhi()
+	require.NoError(t, err)
+	assert.Equal(t, "丙子", month.String())
+
+	// A leap 3rd month of 2012 exists only in the Korean calendar.
+	solar := time.Date(2012, 5, 1, 12, 0, 0, 0, time.UTC)
+	lunar, err = KoreanCalendar().ToLunar(solar)
+	require.NoError(t, err)
+	require.Equal(t, NewLunarDate(2012, 3, 11, true), lunar.Date())
+	day, err = lunar.DayGanZhi()
+	require.NoError(t, err)
+	assert.Equal(t, DayGanZhi(solar), day)
+	hour, err = lunar.HourGanZhi()
+	require.NoError(t, err)
+	assert.Equal(t, HourGanZhi(solar), hour)
+	month, err = lunar.MonthGanZhi()
+	require.NoError(t, err)
+	assert.Equal(t, MonthGanZhi(solar), month)
+}
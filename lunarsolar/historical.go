@@ -0,0 +1,59 @@
+package lunarsolar
+
+const (
+	// shixianMinYear is the first year of the Shixian calendar, adopted by
+	// the Qing on taking Beijing.
+	shixianMinYear = 1645
+	// shixianMaxYear is the last year of the Qing dynasty.
+	shixianMaxYear = 1911
+)
+
+var (
+	shixian = newAstronomicalCalendar(beijingMeanTime, shixianMinYear, shixianMaxYear, false)
+	datong  = newAstronomicalCalendar(beijingMeanTime, MinYear, shixianMinYear-1, true)
+)
+
+// historical returns the calendar in use in a lunar year before those of the
+// table.
+func historical(year int) *AstronomicalCalendar {
+	if year < shixianMinYear {
+		return datong
+	}
+	return shixian
+}
+
+// historicalOn returns the calendar in use on the day with the Julian Day
+// Number, which must be before the table.
+func historicalOn(jdn int) *AstronomicalCalendar {
+	if jdn < shixian.year(shixianMinYear).months[0].start {
+		return datong
+	}
+	return shixian
+}
+
+// ShixianCalendar returns the Shixian calendar (时宪历) of the Qing dynasty,
+// for the years 1645 to 1911. It introduced the rules the Chinese calendar
+// still follows: months begin on the day of the true new moon, and the leap
+// month is the one without a major solar term, found from the apparent
+// longitude of the sun. Days are reckoned at Beijing mean time, UTC+7:45:40.
+//
+// The calendar is computed with modern astronomy. The Qing astronomers used
+// the less accurate theories of their time, so in a few months a new moon or
+// solar term close to midnight fell on a different day than computed here.
+func ShixianCalendar() *AstronomicalCalendar {
+	return shixian
+}
+
+// DatongCalendar returns the Datong calendar (大统历) of the Ming dynasty,
+// for the years 1368 to 1644 in which it was in force. Its months begin on the day of the true new
+// moon, as in the Shixian calendar, but its solar terms are mean terms that
+// divide the year evenly from the winter solstice, so its leap months can
+// fall a month or more from those of the later rules. Days are reckoned at
+// Beijing mean time, UTC+7:45:40.
+//
+// As with ShixianCalendar it is computed with modern astronomy, so dates
+// near a new moon at midnight may differ from the calendars that were
+// issued.
+func DatongCalendar() *AstronomicalCalendar {
+	return datong
+}
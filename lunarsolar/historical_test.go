@@ -0,0 +1,190 @@
+package lunarsolar
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoricalChineseCalendar(t *testing.T) {
+	cal := ChineseCalendar()
+	for _, tc := range []struct {
+		scenario string
+		solar    time.Time
+		lunar    LunarDate
+	}{
+		{
+			scenario: "birth of the Qianlong Emperor",
+			solar:    time.Date(1711, 9, 25, 0, 0, 0, 0, time.UTC),
+			lunar:    NewLunarDate(1711, 8, 13, false),
+		},
+		{
+			scenario: "birth of the Kangxi Emperor",
+			solar:    time.Date(1654, 5, 4, 0, 0, 0, 0, time.UTC),
+			lunar:    NewLunarDate(1654, 3, 18, false),
+		},
+		{
+			scenario: "first day of the Datong calendar",
+			solar:    time.Date(1368, 1, 28, 0, 0, 0, 0, time.UTC),
+			lunar:    NewLunarDate(1368, 1, 1, false),
+		},
+		{
+			scenario: "founding of the Ming",
+			solar:    time.Date(1368, 1, 31, 0, 0, 0, 0, time.UTC),
+			lunar:    NewLunarDate(1368, 1, 4, false),
+		},
+		{
+			scenario: "death of the Chongzhen Emperor",
+			solar:    time.Date(1644, 4, 25, 0, 0, 0, 0, time.UTC),
+			lunar:    NewLunarDate(1644, 3, 19, false),
+		},
+		{
+			scenario: "last day of the Datong calendar",
+			solar:    time.Date(1645, 1, 27, 0, 0, 0, 0, time.UTC),
+			lunar:    NewLunarDate(1644, 12, 30, false),
+		},
+		{
+			scenario: "first day of the Shixian calendar",
+			solar:    time.Date(1645, 1, 28, 0, 0, 0, 0, time.UTC),
+			lunar:    NewLunarDate(1645, 1, 1, false),
+		},
+		{
+			scenario: "last day before the table",
+			solar:    time.Date(1900, 1, 30, 0, 0, 0, 0, time.UTC),
+			lunar:    NewLunarDate(1899, 12, 30, false),
+		},
+		{
+			scenario: "first day of the table",
+			solar:    time.Date(1900, 1, 31, 0, 0, 0, 0, time.UTC),
+			lunar:    NewLunarDate(1900, 1, 1, false),
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			lunar, err := cal.ToLunar(tc.solar)
+			require.NoError(t, err)
+			assert.Equal(t, tc.lunar, lunar.Date())
+
+			solar, err := cal.ToSolar(lunar)
+			require.NoError(t, err)
+			assert.Equal(t, tc.solar, solar)
+		})
+	}
+
+	_, err := cal.ToLunar(time.Date(1367, 6, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, errors.Is(err, ErrYearOutOfRange), err)
+	_, err = cal.ToLunar(time.Date(2101, 6, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, errors.Is(err, ErrYearOutOfRange), err)
+}
+
+// Every day across the changes of calendar converts and back without a gap.
+func TestHistoricalChineseCalendarContinuous(t *testing.T) {
+	cal := ChineseCalendar()
+	for _, start := range []time.Time{
+		time.Date(1644, 6, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1899, 6, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		prev, err := cal.ToLunar(start)
+		require.NoError(t, err)
+		for day := start.AddDate(0, 0, 1); day.Before(start.AddDate(1, 0, 0)); day = day.AddDate(0, 0, 1) {
+			lunar, err := cal.ToLunar(day)
+			require.NoError(t, err)
+			solar, err := cal.ToSolar(lunar)
+			require.NoError(t, err)
+			require.Equal(t, day, solar)
+
+			if lunar.Day() != 1 {
+				require.Equal(t, prev.Day()+1, lunar.Day(), day)
+			}
+			prev = lunar
+		}
+	}
+}
+
+// The table follows the Shixian rules until the end of the Qing, apart from
+// the 3rd month of 1906, which the calendar of the time ended a day late.
+func TestShixianCalendarMatchesTable(t *testing.T) {
+	cal := ShixianCalendar()
+	for year := tableMinYear; year <= cal.MaxYear(); year++ {
+		if year == 1906 {
+			continue
+		}
+		months, err := cal.Months(year)
+		require.NoError(t, err)
+
+		info, _ := yearInfo(year)
+		require.Equal(t, infoMonthCount(info), len(months), year)
+		for i, m := range months {
+			month, isLeap := infoMonthAt(info, i)
+			assert.Equal(t, month, m.Month, "%d %d", year, i)
+			assert.Equal(t, isLeap, m.IsLeap, "%d %d", year, i)
+			assert.Equal(t, infoMonthDays(info, i), m.Days, "%d %d", year, i)
+		}
+	}
+}
+
+// The major terms of the Datong calendar divide the year evenly, up to the
+// difference between the tropical year and the interval between solstices.
+func TestDatongCalendar(t *testing.T) {
+	months, err := DatongCalendar().Months(1644)
+	require.NoError(t, err)
+	for i := 1; i < len(months); i++ {
+		terms := months[i].MajorTerms
+		prev := months[i-1].MajorTerms
+		if len(terms) == 0 || len(prev) == 0 {
+			continue
+		}
+		assert.InDelta(t, tropicalYear/12, terms[0].Sub(prev[0]).Hours()/24, 0.01)
+	}
+	_, err = DatongCalendar().LeapMonth(shixianMinYear)
+	assert.True(t, errors.Is(err, ErrYearOutOfRange), err)
+	// Before the Ming the calendar isn't extended.
+	_, err = DatongCalendar().LeapMonth(MinYear - 1)
+	assert.True(t, errors.Is(err, ErrYearOutOfRange), err)
+	_, err = LeapMonth(MinYear - 1)
+	assert.True(t, errors.Is(err, ErrYearOutOfRange), err)
+}
+
+// The package functions fall back to the historical calendars before the
+// table.
+func TestHistoricalPackageFunctions(t *testing.T) {
+	solar := time.Date(1850, 6, 1, 0, 0, 0, 0, time.UTC)
+	lunar, err := SolarToLunar(solar)
+	require.NoError(t, err)
+	assert.Equal(t, NewLunarDate(1850, 4, 21, false), lunar.Date())
+	back, err := LunarToSolar(lunar)
+	require.NoError(t, err)
+	assert.Equal(t, solar, back)
+
+	b, err := json.Marshal(lunar)
+	require.NoError(t, err)
+	assert.Equal(t, `"1850-04-21T00:00:00Z"`, string(b))
+	var decoded LunarTime
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, lunar, decoded)
+
+	later, err := lunar.AddDays(30)
+	require.NoError(t, err)
+	assert.Equal(t, NewLunarDate(1850, 5, 22, false), later.Date())
+	d, err := later.Sub(lunar)
+	require.NoError(t, err)
+	assert.Equal(t, 30*24*time.Hour, d)
+
+	leap, err := LeapMonth(1851)
+	require.NoError(t, err)
+	assert.Equal(t, 8, leap)
+	days, err := DaysInMonth(1851, 8, true)
+	require.NoError(t, err)
+	assert.Equal(t, 29, days)
+	months, err := MonthsOf(1851)
+	require.NoError(t, err)
+	require.Len(t, months, 13)
+	assert.Equal(t, LunarMonth{Year: 1851, Month: 8, IsLeap: true, Start: time.Date(1851, 9, 25, 0, 0, 0, 0, time.UTC), Days: 29}, months[8])
+
+	age, err := NominalAge(lunar, time.Date(1851, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 2, age)
+}
@@ -0,0 +1,65 @@
+package lunarsolar
+
+import (
+	"fmt"
+	"time"
+)
+
+var japanese = NewAstronomicalCalendar(9 * time.Hour)
+
+// JapaneseCalendar returns the Japanese old calendar (旧暦, kyūreki) as it is
+// commonly computed today: by the rules of the Chinese calendar, reckoning
+// days at Japan Standard Time, UTC+9. Japan replaced it with the Gregorian
+// calendar in 1873, but it is still used for rokuyō and traditional events.
+//
+// In 2033 the last official rules (Tenpō) fail to give a month its number;
+// the calendar follows the usual resolution, a leap 11th month.
+func JapaneseCalendar() *AstronomicalCalendar {
+	return japanese
+}
+
+// RokuyoDay is one of the six days of rokuyō (六曜), a cycle of lucky and
+// unlucky days used in Japan to choose dates for weddings, funerals and
+// other events.
+type RokuyoDay int
+
+const (
+	Sensho     RokuyoDay = iota // 先勝, lucky in the morning
+	Tomobiki                    // 友引, avoided for funerals
+	Senbu                       // 先負, lucky in the afternoon
+	Butsumetsu                  // 仏滅, the most unlucky day
+	Taian                       // 大安, the luckiest day
+	Shakko                      // 赤口, lucky only at noon
+)
+
+var rokuyoNames = [...]struct{ japanese, romaji string }{
+	{"先勝", "Sensho"},
+	{"友引", "Tomobiki"},
+	{"先負", "Senbu"},
+	{"仏滅", "Butsumetsu"},
+	{"大安", "Taian"},
+	{"赤口", "Shakko"},
+}
+
+// String returns the name of the day in Japanese, e.g. "大安".
+func (r RokuyoDay) String() string {
+	return rokuyoNames[mod(int(r), 6)].japanese
+}
+
+// Romaji returns the name of the day in romaji, e.g. "Taian".
+func (r RokuyoDay) Romaji() string {
+	return rokuyoNames[mod(int(r), 6)].romaji
+}
+
+// Rokuyo returns the rokuyō of the solar date of t. The cycle restarts with
+// Sensho on the 1st of each kyūreki month, advancing the starting day by one
+// each month, so it is (month + day) mod 6 of the JapaneseCalendar date. A
+// leap month counts as the month it repeats. Only the year, month and day of
+// t are used.
+func Rokuyo(t time.Time) (RokuyoDay, error) {
+	lunar, err := japanese.ToLunar(t)
+	if err != nil {
+		return 0, fmt.Errorf("lunarsolar: rokuyō of %v: %w", t.Format("2006-01-02"), err)
+	}
+	return RokuyoDay(mod(lunar.Month()+lunar.Day()-2, 6)), nil
+}
@@ -0,0 +1,56 @@
+package lunarsolar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJapaneseCalendar(t *testing.T) {
+	lunar, err := JapaneseCalendar().ToLunar(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, NewLunarDate(2023, 11, 20, false), lunar.Date())
+
+	leap, err := JapaneseCalendar().LeapMonth(2033)
+	require.NoError(t, err)
+	assert.Equal(t, 11, leap)
+}
+
+func TestRokuyo(t *testing.T) {
+	for _, tc := range []struct {
+		scenario string
+		solar    time.Time
+		expected RokuyoDay
+	}{
+		{
+			scenario: "first day of the old year",
+			solar:    time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+			expected: Sensho,
+		},
+		{
+			scenario: "new year's day",
+			solar:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: Shakko,
+		},
+		{
+			scenario: "first day of the 6th month",
+			solar:    time.Date(2024, 7, 6, 0, 0, 0, 0, time.UTC),
+			expected: Shakko,
+		},
+		{
+			scenario: "taian",
+			solar:    time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+			expected: Taian,
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			r, err := Rokuyo(tc.solar)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, r, r.Romaji())
+		})
+	}
+	assert.Equal(t, "大安", Taian.String())
+	assert.Equal(t, "Butsumetsu", Butsumetsu.Romaji())
+}
@@ -0,0 +1,112 @@
+package lunarsolar
+
+import (
+	"fmt"
+	"time"
+)
+
+// SolarToJDN returns the Julian Day Number of the solar date of t, the day
+// that begins at noon UT of that date in the proleptic Gregorian calendar.
+// Only the year, month and day of t are used.
+func SolarToJDN(t time.Time) int {
+	year, month, d := t.Date()
+	return civilToJDN(year, month, d)
+}
+
+// JDNToSolar returns midnight in loc of the solar date with the Julian Day
+// Number.
+func JDNToSolar(jdn int, loc *time.Location) time.Time {
+	year, month, d := jdnToCivil(jdn)
+	return time.Date(year, month, d, 0, 0, 0, 0, loc)
+}
+
+// LunarToJDN returns the Julian Day Number of the solar date on which the
+// lunar date falls. It returns the errors of LunarDate.Validate if the date
+// does not exist.
+func LunarToJDN(d LunarDate) (int, error) {
+	if d.Year < tableMinYear {
+		return historical(d.Year).jdnOf(d)
+	}
+	if err := d.Validate(); err != nil {
+		return 0, err
+	}
+	info, _ := yearInfo(d.Year)
+	jdn := newYearJDN(d.Year, info) + d.Day - 1
+	for i := 0; i < infoMonthIndex(info, d.Month, d.IsLeap); i++ {
+		jdn += infoMonthDays(info, i)
+	}
+	return jdn, nil
+}
+
+// JDNToLunar returns the lunar date that falls on the Julian Day Number. It
+// returns ErrYearOutOfRange if the date is not within lunar years MinYear to
+// MaxYear.
+func JDNToLunar(jdn int) (LunarDate, error) {
+	if jdn < newYearJDN(tableMinYear, yearTable[0]) {
+		return historicalOn(jdn).lunarOn(jdn)
+	}
+	year, _, _ := jdnToCivil(jdn)
+	lunarYear := year
+	if info, ok := yearInfo(year); !ok || jdn < newYearJDN(year, info) {
+		lunarYear--
+	}
+	info, ok := yearInfo(lunarYear)
+	if !ok {
+		return LunarDate{}, fmt.Errorf("%w: %s", ErrYearOutOfRange, JDNToSolar(jdn, time.UTC).Format("2006-01-02"))
+	}
+
+	offset := jdn - newYearJDN(lunarYear, info)
+	i := 0
+	for ; i < infoMonthCount(info) && offset >= infoMonthDays(info, i); i++ {
+		offset -= infoMonthDays(info, i)
+	}
+	if i == infoMonthCount(info) {
+		// Past the end of lunar year MaxYear.
+		return LunarDate{}, fmt.Errorf("%w: %s", ErrYearOutOfRange, JDNToSolar(jdn, time.UTC).Format("2006-01-02"))
+	}
+	month, isLeap := infoMonthAt(info, i)
+	return NewLunarDate(lunarYear, month, offset+1, isLeap), nil
+}
+
+// JDN returns the Julian Day Number of the lunar date of t in its calendar,
+// see LunarToJDN.
+func (t LunarTime) JDN() (int, error) {
+	return jdnIn(t.cal, t.date)
+}
+
+// jdnIn returns the Julian Day Number of the lunar date in the calendar c,
+// the Chinese calendar if c is nil.
+func jdnIn(c Calendar, d LunarDate) (int, error) {
+	switch c := c.(type) {
+	case nil, chineseCalendar:
+		return LunarToJDN(d)
+	case *AstronomicalCalendar:
+		return c.jdnOf(d)
+	}
+	solar, err := c.ToSolar(d.At(0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return 0, err
+	}
+	return SolarToJDN(solar), nil
+}
+
+// lunarIn returns the lunar date that falls on the Julian Day Number in the
+// calendar c, the Chinese calendar if c is nil.
+func lunarIn(c Calendar, jdn int) (LunarDate, error) {
+	switch c := c.(type) {
+	case nil, chineseCalendar:
+		return JDNToLunar(jdn)
+	case *AstronomicalCalendar:
+		return c.lunarOn(jdn)
+	}
+	t, err := c.ToLunar(JDNToSolar(jdn, time.UTC))
+	if err != nil {
+		return LunarDate{}, err
+	}
+	return t.date, nil
+}
+
+// newYearJDN returns the Julian Day Number of the lunar new year.
+func newYearJDN(year int, info uint32) int {
+	return civilToJDN(year, time.January, 1) + infoNewYearOffset(info)
+}
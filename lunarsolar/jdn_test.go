@@ -0,0 +1,96 @@
+package lunarsolar
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolarJDN(t *testing.T) {
+	assert.Equal(t, 2451545, SolarToJDN(time.Date(2000, 1, 1, 23, 59, 0, 0, time.UTC)))
+	assert.Equal(t, 2458874, SolarToJDN(time.Date(2020, 1, 25, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2020, 1, 25, 0, 0, 0, 0, time.UTC), JDNToSolar(2458874, time.UTC))
+}
+
+func TestLunarJDN(t *testing.T) {
+	for _, tc := range []struct {
+		scenario    string
+		lunar       LunarDate
+		expected    int
+		expectedErr error
+	}{
+		{
+			scenario: "new year",
+			lunar:    NewLunarDate(2020, 1, 1, false),
+			expected: 2458874,
+		},
+		{
+			scenario: "leap month",
+			lunar:    NewLunarDate(1998, 5, 2, true),
+			expected: SolarToJDN(time.Date(1998, 6, 25, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			scenario:    "no such leap month",
+			lunar:       NewLunarDate(1998, 4, 2, true),
+			expectedErr: ErrNoSuchLeapMonth,
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			jdn, err := LunarToJDN(tc.lunar)
+			if tc.expectedErr != nil {
+				assert.True(t, errors.Is(err, tc.expectedErr), err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, jdn)
+
+			d, err := JDNToLunar(jdn)
+			require.NoError(t, err)
+			assert.Equal(t, tc.lunar, d)
+		})
+	}
+
+	_, err := JDNToLunar(SolarToJDN(time.Date(1368, 1, 27, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, errors.Is(err, ErrYearOutOfRange), err)
+	_, err = JDNToLunar(SolarToJDN(time.Date(2101, 1, 29, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, errors.Is(err, ErrYearOutOfRange), err)
+}
+
+func TestSubCompare(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// 2021-03-14 is the first day of daylight saving time in New York, lunar
+	// 2021-02-02, and is 23 hours long.
+	a := NewLunarDate(2021, 2, 2, false).At(0, 0, 0, 0, ny)
+	b := NewLunarDate(2021, 2, 3, false).At(0, 0, 0, 0, ny)
+	d, err := b.Sub(a)
+	require.NoError(t, err)
+	assert.Equal(t, 23*time.Hour, d)
+
+	// The leap month comes after the month it repeats.
+	leap := NewLunarDate(2020, 4, 1, true).At(0, 0, 0, 0, time.UTC)
+	regular := NewLunarDate(2020, 4, 29, false).At(23, 0, 0, 0, time.UTC)
+	assert.True(t, regular.Before(leap))
+	assert.True(t, leap.After(regular))
+	assert.True(t, leap.Equal(NewLunarDate(2020, 4, 1, true).At(1, 0, 0, 0, time.FixedZone("", 60*60))))
+
+	// Across zones the later lunar date can be the earlier instant, 23:00 on
+	// lunar 2019-12-07 at UTC-10 coming after midnight on 2019-12-08 at
+	// UTC+14.
+	west := NewLunarDate(2019, 12, 7, false).At(23, 0, 0, 0, time.FixedZone("", -10*60*60))
+	east := NewLunarDate(2019, 12, 8, false).At(0, 0, 0, 0, time.FixedZone("", 14*60*60))
+	d, err = west.Sub(east)
+	require.NoError(t, err)
+	assert.Equal(t, 23*time.Hour, d)
+	assert.True(t, west.After(east))
+	assert.True(t, east.Before(west))
+	assert.False(t, west.Equal(east))
+	assert.True(t, west.Equal(NewLunarDate(2019, 12, 8, false).At(9, 0, 0, 0, time.UTC)))
+
+	_, err = leap.Sub(NewLunarDate(2019, 4, 1, true).At(0, 0, 0, 0, time.UTC))
+	assert.True(t, errors.Is(err, ErrNoSuchLeapMonth), err)
+}
@@ -0,0 +1,18 @@
+package lunarsolar
+
+import "time"
+
+var korean = NewAstronomicalCalendar(9 * time.Hour)
+
+// KoreanCalendar returns the Korean lunisolar calendar (음력). It follows the
+// same rules as the Chinese calendar, but reckons days at Korea Standard
+// Time, UTC+9, so it differs whenever a new moon or major solar term falls
+// between midnight in China and midnight in Korea. Seollal of 1997 was on
+// February 8, a day after Chinese New Year, and 2012 repeated the 3rd month
+// rather than the 4th.
+//
+// The calendar uses UTC+9 for every year, including those in which Korea
+// kept UTC+8:30.
+func KoreanCalendar() *AstronomicalCalendar {
+	return korean
+}
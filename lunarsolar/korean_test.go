@@ -0,0 +1,97 @@
+package lunarsolar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKoreanCalendar(t *testing.T) {
+	k := KoreanCalendar()
+	for _, tc := range []struct {
+		scenario string
+		solar    time.Time
+		expected LunarDate
+		chinese  LunarDate
+	}{
+		{
+			scenario: "seollal a day after chinese new year",
+			solar:    time.Date(1997, 2, 7, 0, 0, 0, 0, time.UTC),
+			expected: NewLunarDate(1996, 12, 30, false),
+			chinese:  NewLunarDate(1997, 1, 1, false),
+		},
+		{
+			scenario: "different leap month",
+			solar:    time.Date(2012, 5, 1, 0, 0, 0, 0, time.UTC),
+			expected: NewLunarDate(2012, 3, 11, true),
+			chinese:  NewLunarDate(2012, 4, 11, false),
+		},
+		{
+			scenario: "same date",
+			solar:    time.Date(2020, 12, 20, 0, 0, 0, 0, time.UTC),
+			expected: NewLunarDate(2020, 11, 6, false),
+			chinese:  NewLunarDate(2020, 11, 6, false),
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			lunar, err := k.ToLunar(tc.solar)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, lunar.Date())
+
+			chinese, err := SolarToLunar(tc.solar)
+			require.NoError(t, err)
+			assert.Equal(t, tc.chinese, chinese.Date())
+		})
+	}
+
+	leap, err := k.LeapMonth(2017)
+	require.NoError(t, err)
+	assert.Equal(t, 5, leap)
+
+	// A leap 3rd month birthday falls in the regular 3rd month of 2013.
+	birth := NewLunarDate(2012, 3, 11, true).At(0, 0, 0, 0, time.UTC)
+	next, err := birth.AddYearsIn(k, 1)
+	require.NoError(t, err)
+	assert.Equal(t, NewLunarDate(2013, 3, 11, false), next.Date())
+	assert.Error(t, NewLunarDate(2012, 4, 1, true).ValidateIn(k))
+}
+
+// A date converted by the Korean calendar keeps counting in it, though the
+// Chinese calendar has no leap 3rd month in 2012.
+func TestKoreanLunarTime(t *testing.T) {
+	k := KoreanCalendar()
+	lunar, err := k.ToLunar(time.Date(2012, 4, 25, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, NewLunarDate(2012, 3, 5, true), lunar.Date())
+	assert.Equal(t, Calendar(k), lunar.Calendar())
+	require.NoError(t, lunar.Validate())
+
+	next, err := lunar.AddDays(1)
+	require.NoError(t, err)
+	assert.Equal(t, NewLunarDate(2012, 3, 6, true), next.Date())
+	d, err := next.Sub(lunar)
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, d)
+	assert.True(t, lunar.Before(next))
+
+	later, err := lunar.AddMonths(1)
+	require.NoError(t, err)
+	assert.Equal(t, NewLunarDate(2012, 4, 5, false), later.Date())
+	later, err = lunar.AddYears(1)
+	require.NoError(t, err)
+	assert.Equal(t, NewLunarDate(2013, 3, 5, false), later.Date())
+
+	text, err := lunar.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "2012-03*-05T00:00:00Z[korean]", string(text))
+	var decoded LunarTime
+	require.NoError(t, decoded.UnmarshalText(text))
+	assert.Equal(t, lunar, decoded)
+
+	chinese, err := SolarToLunar(time.Date(2012, 4, 25, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.True(t, chinese.Equal(lunar))
+	assert.Error(t, decoded.UnmarshalText([]byte("2012-03*-05T00:00:00Z")))
+}
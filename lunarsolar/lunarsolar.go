@@ -5,122 +5,350 @@
 package lunarsolar
 
 import (
+	"errors"
 	"time"
-
-	"github.com/isee15/Lunar-Solar-Calendar-Converter/Go/lunarsolar"
 )
 
+// LunarTime is a lunar date with a time of day and location.
 type LunarTime struct {
-	// Solar calendar time
-	time time.Time
-	// If true, it's a lunar leap year, and this month is being repeated.
-	isLeap bool
+	date LunarDate
+	// Time of day, since midnight.
+	clock time.Duration
+	loc   *time.Location
+	// Calendar of the date, nil for the Chinese calendar.
+	cal Calendar
 }
 
 const (
 	day = time.Hour * 24
 )
 
+var (
+	// ErrYearOutOfRange is returned for dates outside of the lunar years
+	// MinYear to MaxYear.
+	ErrYearOutOfRange = errors.New("lunarsolar: year out of range")
+	// ErrMonthOutOfRange is returned for months outside of 1 to 12.
+	ErrMonthOutOfRange = errors.New("lunarsolar: month out of range")
+	// ErrDayOutOfRange is returned for days before the 1st or past the end of
+	// their month.
+	ErrDayOutOfRange = errors.New("lunarsolar: day out of range")
+	// ErrNoSuchLeapMonth is returned when a date is marked as being in a leap
+	// month, but that month is not repeated in its year.
+	ErrNoSuchLeapMonth = errors.New("lunarsolar: no such leap month")
+)
+
+// NewLunarTime takes a lunar date stored in the year, month and day of t,
+// along with whether the month is a leap month.
+//
+// Deprecated: time.Time normalizes dates that do not exist in the Gregorian
+// calendar, such as the 30th day of the 2nd month, into different dates
+// before NewLunarTime sees them. Use NewLunarDate and LunarDate.At instead.
 func NewLunarTime(t time.Time, isLeap bool) LunarTime {
+	year, month, d := t.Date()
+	return NewLunarDate(year, int(month), d, isLeap).
+		At(t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// At returns the lunar date at the given time of day in loc.
+func (d LunarDate) At(hour, min, sec, nsec int, loc *time.Location) LunarTime {
 	return LunarTime{
-		time:   t,
-		isLeap: isLeap,
+		date: d,
+		clock: time.Duration(hour)*time.Hour +
+			time.Duration(min)*time.Minute +
+			time.Duration(sec)*time.Second +
+			time.Duration(nsec),
+		loc: loc,
 	}
 }
 
-func SolarToLunar(t time.Time) LunarTime {
-	year, month, day := t.Date()
-	lunar := lunarsolar.SolarToLunar(lunarsolar.Solar{
-		SolarYear:  year,
-		SolarMonth: int(month),
-		SolarDay:   day,
-	})
-	return LunarTime{
-		time: time.Date(
-			lunar.LunarYear,
-			time.Month(lunar.LunarMonth),
-			lunar.LunarDay,
-			t.Hour(),
-			t.Minute(),
-			t.Second(),
-			t.Nanosecond(),
-			t.Location()),
-		isLeap: lunar.IsLeap,
-	}
-}
-
-func LunarToSolar(t LunarTime) time.Time {
-	year, month, day := t.time.Date()
-	solar := lunarsolar.LunarToSolar(lunarsolar.Lunar{
-		IsLeap:     t.isLeap,
-		LunarYear:  year,
-		LunarMonth: int(month),
-		LunarDay:   day,
-	})
-	return time.Date(solar.SolarYear,
-		time.Month(solar.SolarMonth),
-		solar.SolarDay,
-		t.time.Hour(),
-		t.time.Minute(),
-		t.time.Second(),
-		t.time.Nanosecond(),
-		t.time.Location())
+// SolarToLunar converts the solar date of t to a lunar date, keeping the time
+// of day and location. It returns ErrYearOutOfRange if the date does not fall
+// within lunar years MinYear to MaxYear.
+//
+// The date is the one t has in its own location, so 23:00 on January 21 in
+// New York converts as January 21 though it was already January 22 in
+// China. This suits dates without a time, such as a birthday written on the
+// solar calendar; to convert an instant use SolarToLunarInstant.
+func SolarToLunar(t time.Time) (LunarTime, error) {
+	d, err := JDNToLunar(SolarToJDN(t))
+	if err != nil {
+		return LunarTime{}, err
+	}
+	return d.At(t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()), nil
+}
+
+// SolarToLunarInstant converts the instant t to the lunar date and time of
+// day at which it fell in China, in the zone in which the calendar was
+// reckoned: China Standard Time, UTC+8, or Beijing mean time before 1929.
+// The result is in that zone, whatever the location of t.
+func SolarToLunarInstant(t time.Time) (LunarTime, error) {
+	return SolarToLunar(t.In(chinaZone(t)))
+}
+
+// LunarToSolar converts the lunar date of t to a solar date, keeping the time
+// of day and location. It returns ErrNoSuchLeapMonth if t is marked as a leap
+// month that its year does not have, or another error if the date does not
+// exist.
+func LunarToSolar(t LunarTime) (time.Time, error) {
+	jdn, err := LunarToJDN(t.date)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.solarOn(jdn), nil
+}
+
+// solar returns the instant of t, converting its date in its calendar.
+func (t LunarTime) solar() (time.Time, error) {
+	jdn, err := t.JDN()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.solarOn(jdn), nil
+}
+
+// solarOn returns the instant of t's time of day on the solar date with the
+// Julian Day Number.
+func (t LunarTime) solarOn(jdn int) time.Time {
+	year, month, d := jdnToCivil(jdn)
+	return time.Date(year, month, d, 0, 0, 0, int(t.clock), t.Location())
 }
 
 // IsLunarLeapMonthPossible takes a lunar date that lacks leap year information
 // and tries to figure out if it was possible that month was repeated.
+//
+// Deprecated: Use HasLeapMonth, which also reports years out of range.
 func IsLunarLeapMonthPossible(t LunarTime) bool {
-	// Take the lunar time assuming it's not a leap month
-	t.isLeap = false
+	hasLeap, err := HasLeapMonth(t.date.Year, t.date.Month)
+	return err == nil && hasLeap
+}
 
-	// Assuming a month can't be longer than 31 days, we jump forward by that
-	// amount plus 1 to land in the next month.
-	diff := 31 - t.time.Day()
-	lunarPlus := t.Add(day * time.Duration(diff+1))
+// Validate checks that the lunar date of t exists in its calendar, see
+// LunarDate.Validate.
+func (t LunarTime) Validate() error {
+	if t.cal == nil {
+		return t.date.Validate()
+	}
+	return t.date.ValidateIn(t.cal)
+}
 
-	return lunarPlus.isLeap
+// Date returns the lunar date of t.
+func (t LunarTime) Date() LunarDate {
+	return t.date
 }
 
-// Solar calendar time
-func (t LunarTime) Time() time.Time {
-	return t.time
+// Year returns the lunar year of t.
+func (t LunarTime) Year() int {
+	return t.date.Year
+}
+
+// Month returns the lunar month of t, from 1 to 12.
+func (t LunarTime) Month() int {
+	return t.date.Month
+}
+
+// Day returns the day of the lunar month of t, from 1 to 30.
+func (t LunarTime) Day() int {
+	return t.date.Day
 }
 
 // If true, it's a lunar leap year, and this month is being repeated.
 func (t LunarTime) IsLeap() bool {
-	return t.isLeap
+	return t.date.IsLeap
 }
 
-func (t LunarTime) Add(d time.Duration) LunarTime {
-	return SolarToLunar(LunarToSolar(t).Add(d))
+// Clock returns the hour, minute and second of the day of t.
+func (t LunarTime) Clock() (hour, min, sec int) {
+	sec = int(t.clock / time.Second)
+	return sec / 3600, sec / 60 % 60, sec % 60
 }
 
-func (t LunarTime) AddDate(years int, months int, days int) LunarTime {
-	return LunarTime{
-		time:   t.time.AddDate(years, months, days),
-		isLeap: t.isLeap,
+// Nanosecond returns the nanosecond offset within the second of t.
+func (t LunarTime) Nanosecond() int {
+	return int(t.clock % time.Second)
+}
+
+// Location returns the location of t, which is UTC for the zero LunarTime.
+func (t LunarTime) Location() *time.Location {
+	if t.loc == nil {
+		return time.UTC
 	}
+	return t.loc
 }
 
-func (t LunarTime) Sub(u LunarTime) time.Duration {
-	return LunarToSolar(t).Sub(LunarToSolar(u))
+// Time returns the lunar date and time of day of t stored in the fields of a
+// time.Time.
+//
+// Deprecated: time.Date normalizes dates that do not exist in the Gregorian
+// calendar, such as the 30th day of the 2nd month, into different dates. Use
+// Date instead.
+func (t LunarTime) Time() time.Time {
+	return time.Date(t.date.Year, time.Month(t.date.Month), t.date.Day, 0, 0, 0, int(t.clock), t.Location())
 }
 
+// String returns the date followed by the time of day and zone, e.g.
+// "1998-05*-02 08:30:00 +0800 CST". The zone is the one in effect on the
+// solar date of t, or if the date does not exist, the name of the location.
+func (t LunarTime) String() string {
+	jdn, err := t.JDN()
+	if err != nil {
+		clock := time.Date(0, time.January, 1, 0, 0, 0, int(t.clock), time.UTC)
+		return t.date.String() + " " + clock.Format("15:04:05.999999999") + " " + t.Location().String()
+	}
+	return t.date.String() + " " + t.solarOn(jdn).Format("15:04:05.999999999 -0700 MST")
+}
+
+// Add returns the lunar date and time of day at the instant d after t, in
+// the calendar of t.
+func (t LunarTime) Add(d time.Duration) (LunarTime, error) {
+	c := t.Calendar()
+	solar, err := c.ToSolar(t)
+	if err != nil {
+		return LunarTime{}, err
+	}
+	u, err := c.ToLunar(solar.Add(d))
+	if err != nil {
+		return LunarTime{}, err
+	}
+	return u.AsCalendar(c), nil
+}
+
+// AddYears returns the same lunar month and day n years after t, in the
+// calendar of t.
+//
+// If t is in a leap month that the target year does not repeat, the result
+// is in the regular month of the same number. If t is on the 30th and the
+// month has 29 days in the target year, the result is on the 29th.
+func (t LunarTime) AddYears(n int) (LunarTime, error) {
+	return t.AddYearsIn(t.Calendar(), n)
+}
+
+// AddMonths returns the same lunar day n months after t in the calendar of
+// t, counting every leap month as a month of its own.
+//
+// If t is on the 30th and the target month has 29 days, the result is on the
+// 29th.
+func (t LunarTime) AddMonths(n int) (LunarTime, error) {
+	if err := t.Validate(); err != nil {
+		return LunarTime{}, err
+	}
+	c := t.Calendar()
+	year := t.date.Year
+	months, err := MonthsIn(c, year)
+	if err != nil {
+		return LunarTime{}, err
+	}
+	i := n
+	for j, m := range months {
+		if m.Month == t.date.Month && m.IsLeap == t.date.IsLeap {
+			i += j
+		}
+	}
+	for i < 0 || i >= len(months) {
+		if i < 0 {
+			year--
+		} else {
+			i -= len(months)
+			year++
+		}
+		months, err = MonthsIn(c, year)
+		if err != nil {
+			return LunarTime{}, err
+		}
+		if i < 0 {
+			i += len(months)
+		}
+	}
+	m := months[i]
+	t.date = NewLunarDate(year, m.Month, t.date.Day, m.IsLeap)
+	if t.date.Day > m.Days {
+		t.date.Day = m.Days
+	}
+	return t, nil
+}
+
+// AddDays returns the lunar date n days after t in the calendar of t, at the
+// same time of day.
+func (t LunarTime) AddDays(n int) (LunarTime, error) {
+	jdn, err := t.JDN()
+	if err != nil {
+		return LunarTime{}, err
+	}
+	d, err := lunarIn(t.cal, jdn+n)
+	if err != nil {
+		return LunarTime{}, err
+	}
+	t.date = d
+	return t, nil
+}
+
+// AddDate returns t with years, months and days added in that order, as by
+// AddYears, AddMonths and AddDays.
+func (t LunarTime) AddDate(years int, months int, days int) (LunarTime, error) {
+	t, err := t.AddYears(years)
+	if err != nil {
+		return LunarTime{}, err
+	}
+	t, err = t.AddMonths(months)
+	if err != nil {
+		return LunarTime{}, err
+	}
+	return t.AddDays(days)
+}
+
+// Sub returns the duration t-u between the instants of t and u, each in its
+// own calendar.
+func (t LunarTime) Sub(u LunarTime) (time.Duration, error) {
+	jdnT, err := t.JDN()
+	if err != nil {
+		return 0, err
+	}
+	jdnU, err := u.JDN()
+	if err != nil {
+		return 0, err
+	}
+	return t.solarOn(jdnT).Sub(u.solarOn(jdnU)), nil
+}
+
+// Equal reports whether t and u are at the same instant, on the same day
+// even if their calendars number it differently.
 func (t LunarTime) Equal(u LunarTime) bool {
-	return t.time.Equal(u.time) && t.isLeap == u.isLeap
+	return t.compare(u) == 0
 }
 
+// Before reports whether t is earlier than u. Times are ordered by their
+// instants, as by Sub, or by their lunar fields if either date does not
+// exist, a leap month coming after the month it repeats.
 func (t LunarTime) Before(u LunarTime) bool {
-	return LunarToSolar(t).Before(LunarToSolar(u))
+	return t.compare(u) < 0
 }
 
+// After reports whether t is later than u.
 func (t LunarTime) After(u LunarTime) bool {
-	return LunarToSolar(t).After(LunarToSolar(u))
+	return t.compare(u) > 0
 }
 
-func (t LunarTime) AsLeap(isLeap bool) LunarTime {
-	return LunarTime{
-		time:   t.time,
-		isLeap: isLeap,
+func (t LunarTime) compare(u LunarTime) int {
+	jdnT, errT := t.JDN()
+	jdnU, errU := u.JDN()
+	if errT != nil || errU != nil {
+		if c := t.date.compare(u.date); c != 0 {
+			return c
+		}
+		// The same date, so any one Julian Day Number orders the times of
+		// day.
+		jdnT, jdnU = 0, 0
 	}
+	tt, ut := t.solarOn(jdnT), u.solarOn(jdnU)
+	switch {
+	case tt.Before(ut):
+		return -1
+	case tt.After(ut):
+		return 1
+	}
+	return 0
+}
+
+func (t LunarTime) AsLeap(isLeap bool) LunarTime {
+	t.date.IsLeap = isLeap
+	return t
 }
@@ -1,48 +1,75 @@
 package lunarsolar
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLunarToSolar(t *testing.T) {
 	for _, tc := range []struct {
-		scenario string
-		lunar    LunarTime
-		expected time.Time
+		scenario    string
+		lunar       LunarTime
+		expected    time.Time
+		expectedErr error
 	}{
 		{
 			scenario: "not leap year",
-			lunar: LunarTime{
-				time:   time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC),
-				isLeap: false,
-			},
+			lunar:    NewLunarDate(2019, 3, 1, false).At(0, 0, 0, 0, time.UTC),
 			expected: time.Date(2019, 4, 5, 0, 0, 0, 0, time.UTC),
 		},
 		{
-			// It appears that calling a non-leap month a leap month will
-			// give an incorrect result.
-			scenario: "not leap year, but calling it such",
-			lunar: LunarTime{
-				time:   time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC),
-				isLeap: true,
-			},
-			expected: time.Date(2019, 2, 5, 0, 0, 0, 0, time.UTC),
+			scenario:    "not leap year, but calling it such",
+			lunar:       NewLunarDate(2019, 3, 1, true).At(0, 0, 0, 0, time.UTC),
+			expectedErr: ErrNoSuchLeapMonth,
+		},
+		{
+			scenario:    "leap year, but not this month",
+			lunar:       NewLunarDate(1998, 4, 2, true).At(0, 0, 0, 0, time.UTC),
+			expectedErr: ErrNoSuchLeapMonth,
 		},
 		{
 			scenario: "leap month",
-			lunar: LunarTime{
-				time:   time.Date(1998, 5, 2, 0, 0, 0, 0, time.UTC),
-				isLeap: true,
-			},
+			lunar:    NewLunarDate(1998, 5, 2, true).At(0, 0, 0, 0, time.UTC),
 			expected: time.Date(1998, 6, 25, 0, 0, 0, 0, time.UTC),
 		},
+		{
+			scenario: "first day of range",
+			lunar:    NewLunarDate(MinYear, 1, 1, false).At(0, 0, 0, 0, time.UTC),
+			expected: time.Date(1368, 1, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			scenario: "first day of the table",
+			lunar:    NewLunarDate(1900, 1, 1, false).At(0, 0, 0, 0, time.UTC),
+			expected: time.Date(1900, 1, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			scenario: "last day of range",
+			lunar:    NewLunarDate(MaxYear, 12, 29, false).At(0, 0, 0, 0, time.UTC),
+			expected: time.Date(2101, 1, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			scenario:    "before range",
+			lunar:       NewLunarDate(MinYear-1, 12, 1, false).At(0, 0, 0, 0, time.UTC),
+			expectedErr: ErrYearOutOfRange,
+		},
+		{
+			scenario:    "after range",
+			lunar:       NewLunarDate(MaxYear+1, 1, 1, false).At(0, 0, 0, 0, time.UTC),
+			expectedErr: ErrYearOutOfRange,
+		},
 	} {
 		t.Run(tc.scenario, func(t *testing.T) {
-			solar := LunarToSolar(tc.lunar)
+			solar, err := LunarToSolar(tc.lunar)
+			if tc.expectedErr != nil {
+				assert.True(t, errors.Is(err, tc.expectedErr), err)
+				return
+			}
+			require.NoError(t, err)
 			assert.Equal(t, tc.expected, solar, fmt.Sprintf("%v\n%v", tc.expected, solar))
 		})
 	}
@@ -52,45 +79,116 @@ func TestSolarToLunar(t *testing.T) {
 	for _, tc := range []struct {
 		scenario string
 		solar    time.Time
-		expected LunarTime
+		expected LunarDate
 	}{
 		{
 			scenario: "not leap year",
 			solar:    time.Date(2019, 4, 5, 0, 0, 0, 0, time.UTC),
-			expected: LunarTime{
-				time:   time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC),
-				isLeap: false,
-			},
+			expected: NewLunarDate(2019, 3, 1, false),
 		},
 		{
 			scenario: "is leap year, not leap month",
 			solar:    time.Date(2020, 1, 26, 0, 0, 0, 0, time.UTC),
-			expected: LunarTime{
-				time:   time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
-				isLeap: false,
-			},
+			expected: NewLunarDate(2020, 1, 2, false),
 		},
 		{
 			scenario: "is leap year, is leap month, but not the duplicate",
 			solar:    time.Date(2020, 4, 23, 0, 0, 0, 0, time.UTC),
-			expected: LunarTime{
-				time:   time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC),
-				isLeap: false,
-			},
+			expected: NewLunarDate(2020, 4, 1, false),
 		},
 		{
 			scenario: "is leap year, is leap month",
 			solar:    time.Date(2020, 5, 23, 0, 0, 0, 0, time.UTC),
-			expected: LunarTime{
-				time:   time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC),
-				isLeap: true,
-			},
+			expected: NewLunarDate(2020, 4, 1, true),
+		},
+		{
+			scenario: "last day of range",
+			solar:    time.Date(2101, 1, 28, 0, 0, 0, 0, time.UTC),
+			expected: NewLunarDate(2100, 12, 29, false),
 		},
 	} {
 		t.Run(tc.scenario, func(t *testing.T) {
-			lunar := SolarToLunar(tc.solar)
-			assert.Equal(t, tc.expected.time, lunar.time, fmt.Sprintf("%v\n%v", tc.expected.time, lunar.time))
-			assert.Equal(t, tc.expected.isLeap, lunar.isLeap)
+			lunar, err := SolarToLunar(tc.solar)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, lunar.Date(), fmt.Sprintf("%v\n%v", tc.expected, lunar.Date()))
+		})
+	}
+}
+
+func TestSolarToLunarOutOfRange(t *testing.T) {
+	for _, tc := range []struct {
+		scenario string
+		solar    time.Time
+	}{
+		{
+			scenario: "day before lunar year 1368",
+			solar:    time.Date(1368, 1, 27, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			scenario: "day after lunar year 2100",
+			solar:    time.Date(2101, 1, 29, 0, 0, 0, 0, time.UTC),
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			_, err := SolarToLunar(tc.solar)
+			assert.True(t, errors.Is(err, ErrYearOutOfRange), err)
+		})
+	}
+}
+
+// Every solar date in the supported range should convert to a lunar date
+// and back again.
+func TestSolarToLunarRoundTrip(t *testing.T) {
+	first := time.Date(1900, 1, 31, 0, 0, 0, 0, time.UTC)
+	last := time.Date(2101, 1, 28, 0, 0, 0, 0, time.UTC)
+	prev := LunarTime{}
+	for solar := first; !solar.After(last); solar = solar.AddDate(0, 0, 1) {
+		lunar, err := SolarToLunar(solar)
+		require.NoError(t, err, solar)
+		require.True(t, prev.Before(lunar), "%v %v", prev, lunar)
+
+		back, err := LunarToSolar(lunar)
+		require.NoError(t, err, lunar)
+		require.Equal(t, solar, back)
+		prev = lunar
+	}
+}
+
+func TestSolarToLunarInstant(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	for _, tc := range []struct {
+		scenario string
+		instant  time.Time
+		expected LunarTime
+		dateOnly LunarDate
+	}{
+		{
+			scenario: "new year's eve in new york",
+			instant:  time.Date(2023, 1, 21, 23, 0, 0, 0, ny),
+			expected: NewLunarDate(2023, 1, 1, false).At(12, 0, 0, 0, chinaStandardTime),
+			dateOnly: NewLunarDate(2022, 12, 30, false),
+		},
+		{
+			scenario: "beijing mean time before 1929",
+			instant:  time.Date(1916, 2, 3, 16, 10, 0, 0, time.UTC),
+			expected: NewLunarDate(1916, 1, 1, false).At(23, 55, 40, 0, beijingMeanZone),
+			dateOnly: NewLunarDate(1916, 1, 1, false),
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			lunar, err := SolarToLunarInstant(tc.instant)
+			require.NoError(t, err)
+			// The string has the zone offset as well as the date and time.
+			assert.Equal(t, tc.expected.String(), lunar.String())
+
+			solar, err := LunarToSolar(lunar)
+			require.NoError(t, err)
+			assert.True(t, tc.instant.Equal(solar), solar)
+
+			lunar, err = SolarToLunar(tc.instant)
+			require.NoError(t, err)
+			assert.Equal(t, tc.dateOnly, lunar.Date())
 		})
 	}
 }
@@ -103,22 +201,22 @@ func TestIsLunarLeapMonthPossible(t *testing.T) {
 	}{
 		{
 			scenario: "not leap year",
-			lunar:    LunarTime{time: time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)},
+			lunar:    NewLunarDate(2019, 3, 1, false).At(0, 0, 0, 0, time.UTC),
 			expected: false,
 		},
 		{
 			scenario: "not leap, month before",
-			lunar:    LunarTime{time: time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)},
+			lunar:    NewLunarDate(2020, 3, 1, false).At(0, 0, 0, 0, time.UTC),
 			expected: false,
 		},
 		{
 			scenario: "possible leap month",
-			lunar:    LunarTime{time: time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)},
+			lunar:    NewLunarDate(2020, 4, 1, false).At(0, 0, 0, 0, time.UTC),
 			expected: true,
 		},
 		{
 			scenario: "not leap, month after",
-			lunar:    LunarTime{time: time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)},
+			lunar:    NewLunarDate(2020, 5, 1, false).At(0, 0, 0, 0, time.UTC),
 			expected: false,
 		},
 	} {
@@ -128,3 +226,298 @@ func TestIsLunarLeapMonthPossible(t *testing.T) {
 		})
 	}
 }
+
+func TestLunarDateString(t *testing.T) {
+	for _, tc := range []struct {
+		scenario string
+		date     LunarDate
+		expected string
+	}{
+		{
+			scenario: "not leap month",
+			date:     NewLunarDate(1958, 11, 6, false),
+			expected: "1958-11-06",
+		},
+		{
+			scenario: "leap month",
+			date:     NewLunarDate(1998, 5, 2, true),
+			expected: "1998-05*-02",
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.date.String())
+		})
+	}
+}
+
+// Lunar dates that don't exist in the Gregorian calendar must not be
+// normalized into a different date.
+func TestLunarTimeKeepsFields(t *testing.T) {
+	lunar := NewLunarDate(2020, 2, 30, false).At(13, 14, 15, 16, time.UTC)
+	assert.Equal(t, NewLunarDate(2020, 2, 30, false), lunar.Date())
+	hour, min, sec := lunar.Clock()
+	assert.Equal(t, []int{13, 14, 15, 16}, []int{hour, min, sec, lunar.Nanosecond()})
+
+	solar, err := LunarToSolar(lunar)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 3, 23, 13, 14, 15, 16, time.UTC), solar)
+}
+
+func TestLunarTimeString(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Lunar 2021-02-02 is 2021-03-14, the first day of daylight saving time,
+	// though 2021-02-02 on the Gregorian calendar is not.
+	lunar := NewLunarDate(2021, 2, 2, false).At(12, 0, 0, 0, ny)
+	assert.Equal(t, "2021-02-02 12:00:00 -0400 EDT", lunar.String())
+
+	lunar = NewLunarDate(2020, 1, 30, false).At(12, 0, 0, 0, ny)
+	assert.Equal(t, "2020-01-30 12:00:00 America/New_York", lunar.String())
+}
+
+func TestLunarDateValidate(t *testing.T) {
+	for _, tc := range []struct {
+		scenario    string
+		date        LunarDate
+		expectedErr error
+	}{
+		{
+			scenario: "valid",
+			date:     NewLunarDate(2019, 3, 1, false),
+		},
+		{
+			scenario: "valid leap month",
+			date:     NewLunarDate(2020, 4, 29, true),
+		},
+		{
+			scenario: "valid 30th",
+			date:     NewLunarDate(2020, 2, 30, false),
+		},
+		{
+			scenario:    "year before range",
+			date:        NewLunarDate(MinYear-1, 1, 1, false),
+			expectedErr: ErrYearOutOfRange,
+		},
+		{
+			scenario:    "year after range",
+			date:        NewLunarDate(MaxYear+1, 1, 1, false),
+			expectedErr: ErrYearOutOfRange,
+		},
+		{
+			scenario:    "month 0",
+			date:        NewLunarDate(2019, 0, 1, false),
+			expectedErr: ErrMonthOutOfRange,
+		},
+		{
+			scenario:    "month 13",
+			date:        NewLunarDate(2019, 13, 1, false),
+			expectedErr: ErrMonthOutOfRange,
+		},
+		{
+			scenario:    "not leap year, but calling it such",
+			date:        NewLunarDate(2019, 3, 1, true),
+			expectedErr: ErrNoSuchLeapMonth,
+		},
+		{
+			scenario:    "day 0",
+			date:        NewLunarDate(2019, 3, 0, false),
+			expectedErr: ErrDayOutOfRange,
+		},
+		{
+			scenario:    "30th of a short month",
+			date:        NewLunarDate(2020, 1, 30, false),
+			expectedErr: ErrDayOutOfRange,
+		},
+		{
+			scenario:    "30th of a short leap month",
+			date:        NewLunarDate(2020, 4, 30, true),
+			expectedErr: ErrDayOutOfRange,
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			date, err := ValidLunarDate(tc.date.Year, tc.date.Month, tc.date.Day, tc.date.IsLeap)
+			if tc.expectedErr != nil {
+				assert.True(t, errors.Is(err, tc.expectedErr), err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.date, date)
+		})
+	}
+}
+
+func TestLunarTimeAddYears(t *testing.T) {
+	for _, tc := range []struct {
+		scenario string
+		lunar    LunarDate
+		years    int
+		expected LunarDate
+	}{
+		{
+			scenario: "normal month",
+			lunar:    NewLunarDate(1958, 11, 6, false),
+			years:    62,
+			expected: NewLunarDate(2020, 11, 6, false),
+		},
+		{
+			scenario: "leap month, target leap month",
+			lunar:    NewLunarDate(1998, 5, 2, true),
+			years:    11,
+			expected: NewLunarDate(2009, 5, 2, true),
+		},
+		{
+			scenario: "leap month, target normal month",
+			lunar:    NewLunarDate(1998, 5, 2, true),
+			years:    12,
+			expected: NewLunarDate(2010, 5, 2, false),
+		},
+		{
+			scenario: "30th, target long month",
+			lunar:    NewLunarDate(2020, 2, 30, false),
+			years:    1,
+			expected: NewLunarDate(2021, 2, 30, false),
+		},
+		{
+			scenario: "30th, target short month",
+			lunar:    NewLunarDate(2020, 2, 30, false),
+			years:    2,
+			expected: NewLunarDate(2022, 2, 29, false),
+		},
+		{
+			scenario: "backwards",
+			lunar:    NewLunarDate(2020, 4, 1, true),
+			years:    -1,
+			expected: NewLunarDate(2019, 4, 1, false),
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			res, err := tc.lunar.At(0, 0, 0, 0, time.UTC).AddYears(tc.years)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, res.Date())
+		})
+	}
+
+	_, err := NewLunarDate(MaxYear, 1, 1, false).At(0, 0, 0, 0, time.UTC).AddYears(1)
+	assert.True(t, errors.Is(err, ErrYearOutOfRange), err)
+	_, err = NewLunarDate(2019, 1, 30, true).At(0, 0, 0, 0, time.UTC).AddYears(1)
+	assert.True(t, errors.Is(err, ErrNoSuchLeapMonth), err)
+}
+
+func TestLunarTimeAddMonths(t *testing.T) {
+	for _, tc := range []struct {
+		scenario string
+		lunar    LunarDate
+		months   int
+		expected LunarDate
+	}{
+		{
+			scenario: "into leap month",
+			lunar:    NewLunarDate(2020, 4, 15, false),
+			months:   1,
+			expected: NewLunarDate(2020, 4, 15, true),
+		},
+		{
+			scenario: "over leap month",
+			lunar:    NewLunarDate(2020, 4, 15, false),
+			months:   2,
+			expected: NewLunarDate(2020, 5, 15, false),
+		},
+		{
+			scenario: "back out of leap month",
+			lunar:    NewLunarDate(2020, 4, 10, true),
+			months:   -1,
+			expected: NewLunarDate(2020, 4, 10, false),
+		},
+		{
+			scenario: "into next year",
+			lunar:    NewLunarDate(2019, 12, 10, false),
+			months:   1,
+			expected: NewLunarDate(2020, 1, 10, false),
+		},
+		{
+			scenario: "into previous year",
+			lunar:    NewLunarDate(2020, 1, 10, false),
+			months:   -1,
+			expected: NewLunarDate(2019, 12, 10, false),
+		},
+		{
+			scenario: "over a leap year",
+			lunar:    NewLunarDate(2020, 1, 1, false),
+			months:   13,
+			expected: NewLunarDate(2021, 1, 1, false),
+		},
+		{
+			scenario: "over several years backwards",
+			lunar:    NewLunarDate(2021, 1, 1, false),
+			months:   -26,
+			expected: NewLunarDate(2018, 12, 1, false),
+		},
+		{
+			scenario: "30th, target long month",
+			lunar:    NewLunarDate(2020, 2, 30, false),
+			months:   1,
+			expected: NewLunarDate(2020, 3, 30, false),
+		},
+		{
+			scenario: "30th, target short month",
+			lunar:    NewLunarDate(2020, 2, 30, false),
+			months:   3,
+			expected: NewLunarDate(2020, 4, 29, true),
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			res, err := tc.lunar.At(0, 0, 0, 0, time.UTC).AddMonths(tc.months)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, res.Date())
+		})
+	}
+
+	_, err := NewLunarDate(MinYear, 1, 1, false).At(0, 0, 0, 0, time.UTC).AddMonths(-1)
+	assert.True(t, errors.Is(err, ErrYearOutOfRange), err)
+}
+
+func TestLunarTimeAddDays(t *testing.T) {
+	for _, tc := range []struct {
+		scenario string
+		lunar    LunarDate
+		days     int
+		expected LunarDate
+	}{
+		{
+			scenario: "within month",
+			lunar:    NewLunarDate(2020, 4, 29, false),
+			days:     1,
+			expected: NewLunarDate(2020, 4, 30, false),
+		},
+		{
+			scenario: "into leap month",
+			lunar:    NewLunarDate(2020, 4, 29, false),
+			days:     2,
+			expected: NewLunarDate(2020, 4, 1, true),
+		},
+		{
+			scenario: "into previous year",
+			lunar:    NewLunarDate(2020, 1, 1, false),
+			days:     -1,
+			expected: NewLunarDate(2019, 12, 30, false),
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			lunar := tc.lunar.At(12, 30, 0, 0, time.UTC)
+			res, err := lunar.AddDays(tc.days)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, res.Date())
+			assert.Equal(t, tc.expected.At(12, 30, 0, 0, time.UTC), res)
+		})
+	}
+}
+
+func TestLunarTimeAddDate(t *testing.T) {
+	lunar := NewLunarDate(2020, 2, 30, false).At(0, 0, 0, 0, time.UTC)
+	res, err := lunar.AddDate(2, 1, 1)
+	require.NoError(t, err)
+	// 2022-02-30 doesn't exist, so AddYears gives 2022-02-29, then AddMonths
+	// 2022-03-29 and AddDays 2022-03-30.
+	assert.Equal(t, NewLunarDate(2022, 3, 30, false), res.Date())
+}
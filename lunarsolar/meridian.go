@@ -0,0 +1,55 @@
+package lunarsolar
+
+import "time"
+
+// beijingMeanTime is the offset of local mean time at the Beijing
+// observatory, 116°25′ east, at which the calendar was reckoned until 1929.
+const beijingMeanTime = 7*time.Hour + 45*time.Minute + 40*time.Second
+
+var (
+	// beijingMeanZone is the zone of Beijing mean time.
+	beijingMeanZone = time.FixedZone(zoneName(int(beijingMeanTime/time.Second)), int(beijingMeanTime/time.Second))
+	// chineseMeridianChange is the instant from which the calendar has been
+	// reckoned at UTC+8, the start of 1929 in China Standard Time.
+	chineseMeridianChange = time.Date(1929, time.January, 1, 0, 0, 0, 0, chinaStandardTime)
+)
+
+var chineseAstronomical = newChineseAstronomicalCalendar()
+
+func newChineseAstronomicalCalendar() *AstronomicalCalendar {
+	c := NewAstronomicalCalendar(8 * time.Hour)
+	c.meridianChange = julianDay(chineseMeridianChange)
+	c.earlyOffset = int(beijingMeanTime / time.Second)
+	c.earlyZone = beijingMeanZone
+	return c
+}
+
+// ChineseAstronomicalCalendar returns the Chinese calendar computed by
+// AstronomicalCalendar with the meridian that was in use at the time. Days
+// were reckoned at Beijing mean time, UTC+7:45:40, until the end of 1928 and
+// at China Standard Time, UTC+8, since. Between 1900 and 1928 this moves the
+// start of three months, the 10th month of 1914, the 1st month of 1916 and
+// the 10th month of 1920, whose new moons fell in the last quarter of an
+// hour of the day in Beijing. The new moon of the 4th month of 1906 did too,
+// but the calendar issued for that year began the month on the next day.
+//
+// The calendar follows the rules of ShixianCalendar throughout; use
+// ChineseCalendar for the calendars that were in use before 1645.
+func ChineseAstronomicalCalendar() *AstronomicalCalendar {
+	return chineseAstronomical
+}
+
+// chinaZone returns the zone in which the Chinese calendar was reckoned at
+// the instant t.
+func chinaZone(t time.Time) *time.Location {
+	if t.Before(chineseMeridianChange) {
+		return beijingMeanZone
+	}
+	return chinaStandardTime
+}
+
+// chinaZoneOn returns the zone in which the Chinese calendar reckoned the
+// Gregorian date.
+func chinaZoneOn(year int, month time.Month, day int) *time.Location {
+	return chinaZone(time.Date(year, month, day, 12, 0, 0, 0, chinaStandardTime))
+}
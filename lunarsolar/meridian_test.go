@@ -0,0 +1,110 @@
+package lunarsolar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The months whose start depends on the meridian. Each begins on the day of
+// its new moon at Beijing mean time, which was already the next day at UTC+8.
+func TestMeridianChange(t *testing.T) {
+	utc8 := NewAstronomicalCalendar(8 * time.Hour)
+	for _, tc := range []struct {
+		scenario string
+		solar    time.Time
+		lunar    LunarDate
+		utc8     LunarDate
+	}{
+		{
+			scenario: "10th month of 1914",
+			solar:    time.Date(1914, 11, 17, 0, 0, 0, 0, time.UTC),
+			lunar:    NewLunarDate(1914, 10, 1, false),
+			utc8:     NewLunarDate(1914, 9, 30, false),
+		},
+		{
+			scenario: "new year of 1916",
+			solar:    time.Date(1916, 2, 3, 0, 0, 0, 0, time.UTC),
+			lunar:    NewLunarDate(1916, 1, 1, false),
+			utc8:     NewLunarDate(1915, 12, 30, false),
+		},
+		{
+			scenario: "10th month of 1920",
+			solar:    time.Date(1920, 11, 10, 0, 0, 0, 0, time.UTC),
+			lunar:    NewLunarDate(1920, 10, 1, false),
+			utc8:     NewLunarDate(1920, 9, 30, false),
+		},
+		{
+			scenario: "after the change",
+			solar:    time.Date(1929, 2, 10, 0, 0, 0, 0, time.UTC),
+			lunar:    NewLunarDate(1929, 1, 1, false),
+			utc8:     NewLunarDate(1929, 1, 1, false),
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			lunar, err := SolarToLunar(tc.solar)
+			require.NoError(t, err)
+			assert.Equal(t, tc.lunar, lunar.Date())
+
+			lunar, err = ChineseAstronomicalCalendar().ToLunar(tc.solar)
+			require.NoError(t, err)
+			assert.Equal(t, tc.lunar, lunar.Date())
+
+			lunar, err = utc8.ToLunar(tc.solar)
+			require.NoError(t, err)
+			assert.Equal(t, tc.utc8, lunar.Date())
+		})
+	}
+}
+
+// With the meridian of each year, the computed calendar agrees with the table
+// from its start, apart from the months near midnight listed in
+// TestAstronomicalCalendarMatchesTable and the 4th month of 1906.
+func TestChineseAstronomicalCalendarMatchesTable(t *testing.T) {
+	cal := ChineseAstronomicalCalendar()
+	for year := tableMinYear; year <= MaxYear; year++ {
+		switch year {
+		case 1906, 2057, 2089, 2097:
+			continue
+		}
+		months, err := cal.Months(year)
+		require.NoError(t, err)
+
+		info, _ := yearInfo(year)
+		require.Equal(t, infoMonthCount(info), len(months), year)
+		start := JDNToSolar(newYearJDN(year, info), time.UTC)
+		for i, m := range months {
+			month, isLeap := infoMonthAt(info, i)
+			assert.Equal(t, month, m.Month, "%d %d", year, i)
+			assert.Equal(t, isLeap, m.IsLeap, "%d %d", year, i)
+			assert.Equal(t, start.Format("2006-01-02"), m.Start.Format("2006-01-02"), "%d %d", year, i)
+			start = start.AddDate(0, 0, m.Days)
+		}
+	}
+
+	months, err := cal.Months(1928)
+	require.NoError(t, err)
+	_, offset := months[0].Start.Zone()
+	assert.Equal(t, int(beijingMeanTime/time.Second), offset)
+	_, offset = months[len(months)-1].Start.Zone()
+	assert.Equal(t, 8*60*60, offset)
+}
+
+// Solar terms before 1929 fall on their date at Beijing mean time.
+func TestSolarTermsBeforeMeridianChange(t *testing.T) {
+	terms := SolarTermsInYear(1917)
+	daxue := terms[Daxue]
+	assert.Equal(t, "1917-12-07 23:46", daxue.Time.Format("2006-01-02 15:04"))
+	assert.Equal(t, "1917-12-08", daxue.Time.In(chinaStandardTime).Format("2006-01-02"))
+
+	term, ok := SolarTermOn(time.Date(1917, 12, 7, 0, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	assert.Equal(t, Daxue, term.Term)
+	_, ok = SolarTermOn(time.Date(1917, 12, 8, 0, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+
+	_, offset := SolarTermsInYear(1929)[Lichun].Time.Zone()
+	assert.Equal(t, 8*60*60, offset)
+}
@@ -0,0 +1,90 @@
+package lunarsolar
+
+import (
+	"math"
+	"time"
+)
+
+// MoonPhase is one of the four principal phases of the moon.
+type MoonPhase int
+
+const (
+	NewMoon MoonPhase = iota
+	FirstQuarter
+	FullMoon
+	LastQuarter
+)
+
+var moonPhaseNames = [...]struct{ english, chinese string }{
+	{"New Moon", "朔"},
+	{"First Quarter", "上弦"},
+	{"Full Moon", "望"},
+	{"Last Quarter", "下弦"},
+}
+
+// String returns the English name of the phase, e.g. "Full Moon".
+func (p MoonPhase) String() string {
+	return moonPhaseNames[mod(int(p), 4)].english
+}
+
+// Chinese returns the name of the phase in Chinese, e.g. "望".
+func (p MoonPhase) Chinese() string {
+	return moonPhaseNames[mod(int(p), 4)].chinese
+}
+
+// MoonPhaseTime is a principal phase of the moon and the instant at which it
+// occurs.
+type MoonPhaseTime struct {
+	Phase MoonPhase
+	// Time is in UTC, to within about a minute.
+	Time time.Time
+}
+
+// MoonPhases returns the principal phases of the moon from start up to but
+// not including end, in order.
+func MoonPhases(start, end time.Time) []MoonPhaseTime {
+	var phases []MoonPhaseTime
+	// Begin a quarter lunation early, as the true phases stray from the mean
+	// ones by up to about 14 hours.
+	k := math.Floor((jdToJDE(julianDay(start))-newMoonEpoch)/synodicMonth*4)/4 - 0.25
+	for ; ; k += 0.25 {
+		t := timeOfJulianDay(jdeToJD(phaseJDE(k)))
+		if !t.Before(end) {
+			break
+		}
+		if t.Before(start) {
+			continue
+		}
+		phase := MoonPhase(mod(int(math.Round(k*4)), 4))
+		phases = append(phases, MoonPhaseTime{Phase: phase, Time: t})
+	}
+	return phases
+}
+
+// MoonPhaseAngle returns the phase angle of the moon at the instant t, the
+// angle in degrees between the sun and the earth as seen from the moon. It
+// is 0 at full moon and 180 at new moon.
+func MoonPhaseAngle(t time.Time) float64 {
+	tc := (jdToJDE(julianDay(t)) - j2000) / 36525
+	// Mean elongation of the moon, and mean anomalies of the sun and moon,
+	// Meeus ch. 47.
+	d := poly(tc, 297.8501921, 445267.1114034, -0.0018819, 1.0/545868, -1.0/113065000) * degree
+	m := poly(tc, 357.5291092, 35999.0502909, -0.0001536, 1.0/24490000) * degree
+	mp := poly(tc, 134.9633964, 477198.8675055, 0.0087414, 1.0/69699, -1.0/14712000) * degree
+	// Meeus (48.4).
+	i := 180 - d/degree -
+		6.289*math.Sin(mp) +
+		2.100*math.Sin(m) -
+		1.274*math.Sin(2*d-mp) -
+		0.658*math.Sin(2*d) -
+		0.214*math.Sin(2*mp) -
+		0.110*math.Sin(d)
+	// Fold into [0, 180].
+	return math.Abs(math.Remainder(i, 360))
+}
+
+// MoonIllumination returns the illuminated fraction of the disk of the moon
+// at the instant t, from 0 at new moon to 1 at full moon.
+func MoonIllumination(t time.Time) float64 {
+	return (1 + math.Cos(MoonPhaseAngle(t)*degree)) / 2
+}
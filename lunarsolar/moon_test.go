@@ -0,0 +1,41 @@
+package lunarsolar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoonPhases(t *testing.T) {
+	phases := MoonPhases(
+		time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC),
+	)
+	expected := []MoonPhaseTime{
+		{Phase: FirstQuarter, Time: time.Date(2020, 1, 3, 4, 45, 0, 0, time.UTC)},
+		{Phase: FullMoon, Time: time.Date(2020, 1, 10, 19, 21, 0, 0, time.UTC)},
+		{Phase: LastQuarter, Time: time.Date(2020, 1, 17, 12, 58, 0, 0, time.UTC)},
+		{Phase: NewMoon, Time: time.Date(2020, 1, 24, 21, 42, 0, 0, time.UTC)},
+	}
+	require.Len(t, phases, len(expected))
+	for i, p := range phases {
+		assert.Equal(t, expected[i].Phase, p.Phase)
+		assert.WithinDuration(t, expected[i].Time, p.Time, time.Minute, p.Phase.String())
+	}
+
+	assert.Empty(t, MoonPhases(phases[1].Time.Add(time.Second), phases[2].Time))
+	assert.Len(t, MoonPhases(phases[1].Time, phases[2].Time.Add(time.Second)), 2)
+}
+
+func TestMoonIllumination(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 48.a, 1992 April 12 at 0h TD.
+	tm := time.Date(1992, 4, 12, 0, 0, 0, 0, time.UTC).Add(-59 * time.Second)
+	assert.InDelta(t, 69.08, MoonPhaseAngle(tm), 0.3)
+	assert.InDelta(t, 0.68, MoonIllumination(tm), 0.005)
+
+	assert.InDelta(t, 1, MoonIllumination(time.Date(2020, 1, 10, 19, 21, 0, 0, time.UTC)), 0.01)
+	assert.InDelta(t, 0, MoonIllumination(time.Date(2020, 1, 24, 21, 42, 0, 0, time.UTC)), 0.01)
+	assert.InDelta(t, 0.5, MoonIllumination(time.Date(2020, 1, 3, 4, 45, 0, 0, time.UTC)), 0.02)
+}
@@ -0,0 +1,246 @@
+package lunarsolar
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrParse is returned when text does not match a layout.
+var ErrParse = errors.New("lunarsolar: cannot parse")
+
+// anyLayouts are tried in order by ParseAny.
+var anyLayouts = []string{
+	LayoutNumeric,
+	"2006/01*/02",
+	"2006.01*.02",
+	"2006年闰正月初二",
+	"2006年闰正月初二日",
+	"2006年闰1月2日",
+	"2006年闰正月2日",
+	LayoutChinese,
+	"二〇〇六年闰1月2日",
+	"丙戌年闰正月初二",
+}
+
+// Parse parses a lunar date written in the layout, as described for
+// LayoutNumeric. The leap markers "*" and "闰" are optional in the text, and
+// the Chinese elements are read in both Simplified and Traditional Chinese.
+// A stem-branch year or zodiac animal must agree with the year, and a
+// stem-branch year alone is not enough to give the year. Hour, minute and
+// second elements are read but not returned.
+//
+// Text that does not match the layout gives an error wrapping ErrParse, and a
+// date that does not exist one of the errors of LunarDate.Validate.
+func Parse(layout, value string) (LunarDate, error) {
+	d, err := parse(layout, value)
+	if err != nil {
+		return LunarDate{}, err
+	}
+	if err := d.Validate(); err != nil {
+		return LunarDate{}, err
+	}
+	return d, nil
+}
+
+// parse is like Parse but does not check that the date exists.
+func parse(layout, value string) (LunarDate, error) {
+	parseErr := func(reason string) error {
+		return fmt.Errorf("%w: %q as %q: %s", ErrParse, value, layout, reason)
+	}
+
+	var (
+		d                         LunarDate
+		hasYear, hasMonth, hasDay bool
+		ganZhi                    GanZhi
+		animal                    Animal
+		hasGanZhi, hasAnimal      bool
+	)
+	rest := value
+	for l := layout; l != ""; {
+		prefix, kind, suffix := nextLayoutElement(l)
+		if !strings.HasPrefix(rest, prefix) {
+			return LunarDate{}, parseErr(fmt.Sprintf("expected %q at %q", prefix, rest))
+		}
+		rest = rest[len(prefix):]
+		l = suffix
+
+		var ok bool
+		switch kind {
+		case layoutNone:
+			ok = true
+		case layoutYear:
+			d.Year, rest, ok = parseDigits(rest, 4)
+			hasYear = true
+		case layoutYearChinese:
+			d.Year, rest, ok = parseChineseDigits(rest)
+			hasYear = true
+		case layoutYearGanZhi:
+			ganZhi, rest, ok = parseGanZhi(rest)
+			hasGanZhi = true
+		case layoutZodiac:
+			animal, rest, ok = parseAnimal(rest)
+			hasAnimal = true
+		case layoutMonth, layoutZeroMonth:
+			d.Month, rest, ok = parseDigits(rest, 2)
+			hasMonth = true
+		case layoutMonthChinese:
+			d.Month, rest, ok = parseName(rest, monthNames)
+			hasMonth = true
+		case layoutLeap:
+			if strings.HasPrefix(rest, "*") {
+				d.IsLeap, rest = true, rest[1:]
+			}
+			ok = true
+		case layoutLeapChinese:
+			for _, leap := range []string{simplified.leap, traditional.leap} {
+				if strings.HasPrefix(rest, leap) {
+					d.IsLeap, rest = true, rest[len(leap):]
+				}
+			}
+			ok = true
+		case layoutDay, layoutZeroDay:
+			d.Day, rest, ok = parseDigits(rest, 2)
+			hasDay = true
+		case layoutDayChinese:
+			d.Day, rest, ok = parseName(rest, dayNames)
+			hasDay = true
+		case layoutHour, layoutMinute, layoutSecond:
+			_, rest, ok = parseDigits(rest, 2)
+		}
+		if !ok {
+			return LunarDate{}, parseErr(fmt.Sprintf("unexpected %q", rest))
+		}
+	}
+	switch {
+	case rest != "":
+		return LunarDate{}, parseErr(fmt.Sprintf("extra text %q", rest))
+	case !hasYear && hasGanZhi:
+		return LunarDate{}, parseErr("a stem-branch year alone does not give the year")
+	case !hasYear || !hasMonth || !hasDay:
+		return LunarDate{}, parseErr("missing year, month or day")
+	case hasGanZhi && YearGanZhi(d.Year) != ganZhi:
+		return LunarDate{}, parseErr(fmt.Sprintf("%d is not a %v year", d.Year, ganZhi))
+	case hasAnimal && YearGanZhi(d.Year).Branch().Animal() != animal:
+		return LunarDate{}, parseErr(fmt.Sprintf("%d is not a year of the %v", d.Year, animal))
+	}
+	return d, nil
+}
+
+// ParseAny parses a lunar date in any of the common numeric and Chinese
+// forms, such as "2023-02*-15", "2023年闰二月十五" or "一九五八年冬月初六". A
+// leading "L" or "农历" is ignored, as is surrounding space.
+func ParseAny(value string) (LunarDate, error) {
+	s := strings.TrimSpace(value)
+	for _, prefix := range []string{"L", "l", "农历", "農曆"} {
+		s = strings.TrimPrefix(s, prefix)
+	}
+	for _, layout := range anyLayouts {
+		d, err := Parse(layout, s)
+		if err == nil {
+			return d, nil
+		}
+		if !errors.Is(err, ErrParse) {
+			// The text matched, but the date does not exist.
+			return LunarDate{}, err
+		}
+	}
+	return LunarDate{}, fmt.Errorf("%w: %q is not a recognized lunar date", ErrParse, value)
+}
+
+// parseDigits reads a number of one to max ASCII digits.
+func parseDigits(s string, max int) (int, string, bool) {
+	n, i := 0, 0
+	for ; i < len(s) && i < max && s[i] >= '0' && s[i] <= '9'; i++ {
+		n = n*10 + int(s[i]-'0')
+	}
+	return n, s[i:], i > 0
+}
+
+// parseChineseDigits reads a number written digit by digit in Chinese, e.g.
+// "一九五八".
+func parseChineseDigits(s string) (int, string, bool) {
+	n, read := 0, false
+	for {
+		digit := -1
+		for i, c := range chineseDigits {
+			if strings.HasPrefix(s, c) {
+				digit = i
+				s = s[len(c):]
+				break
+			}
+		}
+		if digit < 0 {
+			for _, c := range []string{"零", "○"} {
+				if strings.HasPrefix(s, c) {
+					digit = 0
+					s = s[len(c):]
+					break
+				}
+			}
+		}
+		if digit < 0 {
+			return n, s, read
+		}
+		n, read = n*10+digit, true
+	}
+}
+
+func parseGanZhi(s string) (GanZhi, string, bool) {
+	for g := GanZhi(0); g < 60; g++ {
+		if strings.HasPrefix(s, g.String()) {
+			return g, s[len(g.String()):], true
+		}
+	}
+	return 0, s, false
+}
+
+func parseAnimal(s string) (Animal, string, bool) {
+	for _, sc := range []*script{&simplified, &traditional} {
+		for i, name := range sc.animals {
+			if strings.HasPrefix(s, name) {
+				return Animal(i), s[len(name):], true
+			}
+		}
+	}
+	return 0, s, false
+}
+
+// parseName reads the longest of the names at the start of s, returning its
+// value.
+func parseName(s string, names map[string]int) (int, string, bool) {
+	best := ""
+	for name := range names {
+		if len(name) > len(best) && strings.HasPrefix(s, name) {
+			best = name
+		}
+	}
+	if best == "" {
+		return 0, s, false
+	}
+	return names[best], s[len(best):], true
+}
+
+// monthNames and dayNames map the names accepted by Parse to their numbers.
+var (
+	monthNames = map[string]int{"元月": 1, "一月": 1, "十一月": 11, "十二月": 12}
+	dayNames   = map[string]int{"卅": 30, "二十": 20}
+)
+
+func init() {
+	for _, sc := range []*script{&simplified, &traditional} {
+		for i, name := range sc.months {
+			monthNames[name] = i + 1
+		}
+	}
+	for i := 2; i <= 9; i++ {
+		monthNames[chineseDigits[i]+"月"] = i
+	}
+	monthNames["十月"] = 10
+	for day := 1; day <= 30; day++ {
+		dayNames[chineseDay(day)] = day
+	}
+	for day := 21; day <= 29; day++ {
+		dayNames["二十"+chineseDigits[day%10]] = day
+	}
+}
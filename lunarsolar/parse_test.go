@@ -0,0 +1,121 @@
+package lunarsolar
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		scenario    string
+		layout      string
+		value       string
+		expected    LunarDate
+		expectedErr error
+	}{
+		{
+			scenario: "numeric",
+			layout:   LayoutNumeric,
+			value:    "1998-05*-02",
+			expected: NewLunarDate(1998, 5, 2, true),
+		},
+		{
+			scenario: "numeric without leap marker",
+			layout:   LayoutNumeric,
+			value:    "1998-05-02",
+			expected: NewLunarDate(1998, 5, 2, false),
+		},
+		{
+			scenario: "chinese",
+			layout:   LayoutChinese,
+			value:    "一九五八年冬月初六",
+			expected: NewLunarDate(1958, 11, 6, false),
+		},
+		{
+			scenario: "traditional",
+			layout:   "2006年闰正月初二",
+			value:    "2020年閏四月廿九",
+			expected: NewLunarDate(2020, 4, 29, true),
+		},
+		{
+			scenario: "stem-branch year",
+			layout:   "2006丙戌狗年正月初二",
+			value:    "2024甲辰龙年腊月廿九",
+			expected: NewLunarDate(2024, 12, 29, false),
+		},
+		{
+			scenario:    "wrong stem-branch year",
+			layout:      "2006丙戌年正月初二",
+			value:       "2024甲子年腊月三十",
+			expectedErr: ErrParse,
+		},
+		{
+			scenario:    "stem-branch year alone",
+			layout:      LayoutChineseGanZhi,
+			value:       "甲辰年腊月三十",
+			expectedErr: ErrParse,
+		},
+		{
+			scenario:    "literal mismatch",
+			layout:      LayoutNumeric,
+			value:       "1998/05/02",
+			expectedErr: ErrParse,
+		},
+		{
+			scenario:    "extra text",
+			layout:      LayoutNumeric,
+			value:       "1998-05-02 12:00",
+			expectedErr: ErrParse,
+		},
+		{
+			scenario:    "no such leap month",
+			layout:      LayoutNumeric,
+			value:       "1998-04*-02",
+			expectedErr: ErrNoSuchLeapMonth,
+		},
+		{
+			scenario:    "day out of range",
+			layout:      LayoutChinese,
+			value:       "一九九八年闰五月三十",
+			expectedErr: ErrDayOutOfRange,
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			d, err := Parse(tc.layout, tc.value)
+			if tc.expectedErr != nil {
+				assert.True(t, errors.Is(err, tc.expectedErr), err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, d)
+		})
+	}
+}
+
+func TestParseAny(t *testing.T) {
+	for _, tc := range []struct {
+		value    string
+		expected LunarDate
+	}{
+		{value: "一九五八年冬月初六", expected: NewLunarDate(1958, 11, 6, false)},
+		{value: "2023年闰二月十五", expected: NewLunarDate(2023, 2, 15, true)},
+		{value: "L2023-02*-15", expected: NewLunarDate(2023, 2, 15, true)},
+		{value: " 1958/3/15 ", expected: NewLunarDate(1958, 3, 15, false)},
+		{value: "农历1958年十一月初六日", expected: NewLunarDate(1958, 11, 6, false)},
+		{value: "1958年3月15日", expected: NewLunarDate(1958, 3, 15, false)},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			d, err := ParseAny(tc.value)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, d)
+		})
+	}
+
+	_, err := ParseAny("yesterday")
+	assert.True(t, errors.Is(err, ErrParse), err)
+	_, err = ParseAny("1958-04*-15")
+	assert.True(t, errors.Is(err, ErrNoSuchLeapMonth), err)
+}
@@ -0,0 +1,190 @@
+package lunarsolar
+
+import (
+	"math"
+	"time"
+)
+
+// SolarTerm is one of the 24 solar terms (jieqi), the instants at which the
+// apparent ecliptic longitude of the sun reaches a multiple of 15°. They are
+// numbered in the order they occur in a Gregorian year.
+type SolarTerm int
+
+const (
+	Xiaohan     SolarTerm = iota // 小寒 Minor Cold, 285°
+	Dahan                        // 大寒 Major Cold, 300°
+	Lichun                       // 立春 Start of Spring, 315°
+	Yushui                       // 雨水 Rain Water, 330°
+	Jingzhe                      // 惊蛰 Awakening of Insects, 345°
+	Chunfen                      // 春分 Spring Equinox, 0°
+	Qingming                     // 清明 Pure Brightness, 15°
+	Guyu                         // 谷雨 Grain Rain, 30°
+	Lixia                        // 立夏 Start of Summer, 45°
+	Xiaoman                      // 小满 Grain Buds, 60°
+	Mangzhong                    // 芒种 Grain in Ear, 75°
+	Xiazhi                       // 夏至 Summer Solstice, 90°
+	Xiaoshu                      // 小暑 Minor Heat, 105°
+	Dashu                        // 大暑 Major Heat, 120°
+	Liqiu                        // 立秋 Start of Autumn, 135°
+	Chushu                       // 处暑 End of Heat, 150°
+	Bailu                        // 白露 White Dew, 165°
+	Qiufen                       // 秋分 Autumn Equinox, 180°
+	Hanlu                        // 寒露 Cold Dew, 195°
+	Shuangjiang                  // 霜降 Frost's Descent, 210°
+	Lidong                       // 立冬 Start of Winter, 225°
+	Xiaoxue                      // 小雪 Minor Snow, 240°
+	Daxue                        // 大雪 Major Snow, 255°
+	Dongzhi                      // 冬至 Winter Solstice, 270°
+)
+
+var solarTermNames = [...]struct{ pinyin, chinese, english string }{
+	{"Xiaohan", "小寒", "Minor Cold"},
+	{"Dahan", "大寒", "Major Cold"},
+	{"Lichun", "立春", "Start of Spring"},
+	{"Yushui", "雨水", "Rain Water"},
+	{"Jingzhe", "惊蛰", "Awakening of Insects"},
+	{"Chunfen", "春分", "Spring Equinox"},
+	{"Qingming", "清明", "Pure Brightness"},
+	{"Guyu", "谷雨", "Grain Rain"},
+	{"Lixia", "立夏", "Start of Summer"},
+	{"Xiaoman", "小满", "Grain Buds"},
+	{"Mangzhong", "芒种", "Grain in Ear"},
+	{"Xiazhi", "夏至", "Summer Solstice"},
+	{"Xiaoshu", "小暑", "Minor Heat"},
+	{"Dashu", "大暑", "Major Heat"},
+	{"Liqiu", "立秋", "Start of Autumn"},
+	{"Chushu", "处暑", "End of Heat"},
+	{"Bailu", "白露", "White Dew"},
+	{"Qiufen", "秋分", "Autumn Equinox"},
+	{"Hanlu", "寒露", "Cold Dew"},
+	{"Shuangjiang", "霜降", "Frost's Descent"},
+	{"Lidong", "立冬", "Start of Winter"},
+	{"Xiaoxue", "小雪", "Minor Snow"},
+	{"Daxue", "大雪", "Major Snow"},
+	{"Dongzhi", "冬至", "Winter Solstice"},
+}
+
+// chinaStandardTime is the zone in which the Chinese calendar is reckoned.
+var chinaStandardTime = time.FixedZone("CST", 8*60*60)
+
+// String returns the pinyin name of the term, e.g. "Qingming".
+func (s SolarTerm) String() string {
+	return solarTermNames[s.index()].pinyin
+}
+
+// Chinese returns the name of the term in Chinese characters, e.g. "清明".
+func (s SolarTerm) Chinese() string {
+	return solarTermNames[s.index()].chinese
+}
+
+// English returns the English name of the term, e.g. "Pure Brightness".
+func (s SolarTerm) English() string {
+	return solarTermNames[s.index()].english
+}
+
+// Longitude returns the ecliptic longitude of the sun at the term, in
+// degrees.
+func (s SolarTerm) Longitude() float64 {
+	return float64((s.index()+19)%24) * 15
+}
+
+// IsMajor reports whether the term is a major term (zhongqi), one at a
+// multiple of 30°. The major terms decide the numbering of lunar months.
+func (s SolarTerm) IsMajor() bool {
+	return s.index()%2 == 1
+}
+
+func (s SolarTerm) index() int {
+	return mod(int(s), 24)
+}
+
+// solarTermAt returns the term at the longitude, which must be a multiple of
+// 15°.
+func solarTermAt(lon float64) SolarTerm {
+	return SolarTerm((int(math.Round(lon/15)) + 5) % 24)
+}
+
+// SolarTermTime is a solar term and the instant at which it occurs.
+type SolarTermTime struct {
+	Term SolarTerm
+	// Time is in China Standard Time, UTC+8, or before 1929 in Beijing mean
+	// time, UTC+7:45:40, the zones in which the calendar was reckoned.
+	Time time.Time
+}
+
+// SolarTermsInYear returns the 24 solar terms of the Gregorian year in
+// order, from Xiaohan in early January to Dongzhi in late December.
+func SolarTermsInYear(year int) []SolarTermTime {
+	terms := make([]SolarTermTime, 24)
+	// Xiaohan falls around January 5, and the terms are about 15.2 days
+	// apart.
+	jan5 := float64(civilToJDN(year, time.January, 5))
+	for i := range terms {
+		term := SolarTerm(i)
+		jde := sunLongitudeJDE(term.Longitude(), jan5+float64(i)*tropicalYear/24)
+		terms[i] = SolarTermTime{Term: term, Time: solarTermTime(jde)}
+	}
+	return terms
+}
+
+// SolarTermOn returns the solar term that falls on the date of t, in the
+// zone of SolarTermTime.Time, and false if there is none. Only the year,
+// month and day of t are used.
+func SolarTermOn(t time.Time) (SolarTermTime, bool) {
+	year, month, day := t.Date()
+	midnight := time.Date(year, month, day, 0, 0, 0, 0, chinaZoneOn(year, month, day))
+	next := NextSolarTerm(midnight.Add(-time.Nanosecond))
+	if next.Time.Before(midnight.AddDate(0, 0, 1)) {
+		return next, true
+	}
+	return SolarTermTime{}, false
+}
+
+// PreviousSolarTerm returns the last solar term at or before the instant t,
+// comparing t with the Time of the term, which is to the nearest second.
+func PreviousSolarTerm(t time.Time) SolarTermTime {
+	jde := jdToJDE(julianDay(t))
+	lon := sunLongitude(jde)
+	target := math.Floor(lon/15) * 15
+	term := sunLongitudeJDE(target, jde-(lon-target)/360*tropicalYear)
+	if solarTermTime(term).After(t) {
+		// Rounding put the term just after t; take the one before.
+		target = normDegrees(target - 15)
+		term = sunLongitudeJDE(target, term-tropicalYear/24)
+	} else {
+		// Rounding may put the next term at t; take that one.
+		next := normDegrees(target + 15)
+		nextTerm := sunLongitudeJDE(next, term+tropicalYear/24)
+		if !solarTermTime(nextTerm).After(t) {
+			target, term = next, nextTerm
+		}
+	}
+	return SolarTermTime{Term: solarTermAt(target), Time: solarTermTime(term)}
+}
+
+// NextSolarTerm returns the first solar term after the instant t, comparing t
+// with the Time of the term, which is to the nearest second.
+func NextSolarTerm(t time.Time) SolarTermTime {
+	jde := jdToJDE(julianDay(t))
+	lon := sunLongitude(jde)
+	target := normDegrees(math.Floor(lon/15)*15 + 15)
+	term := sunLongitudeJDE(target, jde+math.Remainder(target-lon, 360)/360*tropicalYear)
+	if !solarTermTime(term).After(t) {
+		// Rounding put the term at or just before t; take the one after.
+		target = normDegrees(target + 15)
+		term = sunLongitudeJDE(target, term+tropicalYear/24)
+	} else {
+		// Rounding may put the previous term just after t; take that one.
+		prev := normDegrees(target - 15)
+		prevTerm := sunLongitudeJDE(prev, term-tropicalYear/24)
+		if solarTermTime(prevTerm).After(t) {
+			target, term = prev, prevTerm
+		}
+	}
+	return SolarTermTime{Term: solarTermAt(target), Time: solarTermTime(term)}
+}
+
+func solarTermTime(jde float64) time.Time {
+	t := timeOfJulianDay(jdeToJD(jde))
+	return t.In(chinaZone(t))
+}
@@ -0,0 +1,131 @@
+package lunarsolar
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolarTermNames(t *testing.T) {
+	assert.Equal(t, "Qingming", Qingming.String())
+	assert.Equal(t, "清明", Qingming.Chinese())
+	assert.Equal(t, "Pure Brightness", Qingming.English())
+	assert.Equal(t, 15.0, Qingming.Longitude())
+	assert.Equal(t, 315.0, Lichun.Longitude())
+	assert.Equal(t, 270.0, Dongzhi.Longitude())
+	assert.False(t, Lichun.IsMajor())
+	assert.True(t, Dongzhi.IsMajor())
+	assert.True(t, Chunfen.IsMajor())
+}
+
+func TestSolarTermsInYear(t *testing.T) {
+	terms := SolarTermsInYear(2021)
+	require.Len(t, terms, 24)
+	for i, term := range terms {
+		assert.Equal(t, SolarTerm(i), term.Term)
+		assert.Equal(t, 2021, term.Time.Year(), term.Term)
+		if i > 0 {
+			days := term.Time.Sub(terms[i-1].Time).Hours() / 24
+			assert.True(t, days > 14 && days < 16.5, "%v %v", term.Term, days)
+		}
+	}
+
+	for _, tc := range []struct {
+		term     SolarTerm
+		expected time.Time
+	}{
+		{term: Chunfen, expected: time.Date(2021, 3, 20, 9, 37, 0, 0, time.UTC)},
+		{term: Xiazhi, expected: time.Date(2021, 6, 21, 3, 32, 0, 0, time.UTC)},
+		{term: Qiufen, expected: time.Date(2021, 9, 22, 19, 21, 0, 0, time.UTC)},
+	} {
+		t.Run(tc.term.String(), func(t *testing.T) {
+			diff := terms[tc.term].Time.Sub(tc.expected)
+			assert.True(t, math.Abs(diff.Minutes()) < 1, "%v", terms[tc.term].Time)
+		})
+	}
+}
+
+func TestSolarTermOn(t *testing.T) {
+	for _, tc := range []struct {
+		scenario string
+		date     time.Time
+		expected SolarTerm
+		ok       bool
+	}{
+		{
+			scenario: "qingming",
+			date:     time.Date(2021, 4, 4, 0, 0, 0, 0, time.UTC),
+			expected: Qingming,
+			ok:       true,
+		},
+		{
+			scenario: "lichun",
+			date:     time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC),
+			expected: Lichun,
+			ok:       true,
+		},
+		{
+			scenario: "day after lichun",
+			date:     time.Date(2021, 2, 4, 0, 0, 0, 0, time.UTC),
+			ok:       false,
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			term, ok := SolarTermOn(tc.date)
+			assert.Equal(t, tc.ok, ok)
+			if tc.ok {
+				assert.Equal(t, tc.expected, term.Term)
+			}
+		})
+	}
+}
+
+func TestPreviousNextSolarTerm(t *testing.T) {
+	terms := SolarTermsInYear(2021)
+	qingming := terms[Qingming]
+
+	prev := PreviousSolarTerm(qingming.Time.Add(time.Hour))
+	assert.Equal(t, Qingming, prev.Term)
+	assert.Equal(t, qingming.Time, prev.Time)
+
+	prev = PreviousSolarTerm(qingming.Time.Add(-time.Hour))
+	assert.Equal(t, Chunfen, prev.Term)
+	assert.Equal(t, terms[Chunfen].Time, prev.Time)
+
+	next := NextSolarTerm(qingming.Time.Add(-time.Hour))
+	assert.Equal(t, Qingming, next.Term)
+	assert.Equal(t, qingming.Time, next.Time)
+
+	next = NextSolarTerm(qingming.Time.Add(time.Hour))
+	assert.Equal(t, Guyu, next.Term)
+	assert.Equal(t, terms[Guyu].Time, next.Time)
+
+	// Across the turn of the year.
+	next = NextSolarTerm(terms[Dongzhi].Time.Add(time.Hour))
+	assert.Equal(t, Xiaohan, next.Term)
+	assert.Equal(t, SolarTermsInYear(2022)[Xiaohan].Time, next.Time)
+	prev = PreviousSolarTerm(terms[Xiaohan].Time.Add(-time.Hour))
+	assert.Equal(t, Dongzhi, prev.Term)
+	assert.Equal(t, SolarTermsInYear(2020)[Dongzhi].Time, prev.Time)
+}
+
+// The Time of each term counts as at the term, though it is rounded to the
+// second.
+func TestPreviousNextSolarTermAtTermTime(t *testing.T) {
+	for year := 1950; year < 2050; year++ {
+		terms := SolarTermsInYear(year)
+		for i, term := range terms {
+			assert.Equal(t, term, PreviousSolarTerm(term.Time), "%d %v", year, term.Term)
+			assert.Equal(t, term, NextSolarTerm(term.Time.Add(-time.Second)), "%d %v", year, term.Term)
+			if i > 0 {
+				assert.Equal(t, terms[i-1], PreviousSolarTerm(term.Time.Add(-time.Second)), "%d %v", year, term.Term)
+			}
+			if i < len(terms)-1 {
+				assert.Equal(t, terms[i+1], NextSolarTerm(term.Time), "%d %v", year, term.Term)
+			}
+		}
+	}
+}
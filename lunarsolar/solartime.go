@@ -0,0 +1,33 @@
+package lunarsolar
+
+import (
+	"math"
+	"time"
+)
+
+// EquationOfTime returns the equation of time at the instant t, the amount
+// by which apparent solar time, as shown by a sundial, is ahead of mean
+// solar time. It ranges from about -14 minutes in February to +16 minutes in
+// November.
+func EquationOfTime(t time.Time) time.Duration {
+	jde := jdToJDE(julianDay(t))
+	tc := (jde - j2000) / 36525
+	// Mean longitude of the sun, Meeus (28.2).
+	l0 := normDegrees(poly(tc/10, 280.4664567, 360007.6982779, 0.03032028, 1.0/49931, -1.0/15300, -1.0/2000000))
+	lambda := sunLongitude(jde) * degree
+	dpsi := nutationInLongitude(tc)
+	eps := meanObliquity(tc) + nutationInObliquity(tc)
+	alpha := math.Atan2(math.Cos(eps)*math.Sin(lambda), math.Cos(lambda)) / degree
+	// Meeus (28.3).
+	e := math.Remainder(l0-0.0057183-alpha+dpsi/degree*math.Cos(eps), 360)
+	return time.Duration(e * 4 * float64(time.Minute))
+}
+
+// TrueSolarTime returns the instant t in a zone whose clock reads local
+// apparent solar time at the longitude, in degrees east of Greenwich. The
+// hour of the result is the one a sundial there would show. The zone offset
+// is rounded to the second.
+func TrueSolarTime(t time.Time, longitude float64) time.Time {
+	offset := time.Duration(longitude*4*float64(time.Minute)) + EquationOfTime(t)
+	return t.In(time.FixedZone("LAT", int(offset.Round(time.Second)/time.Second)))
+}
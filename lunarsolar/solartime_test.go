@@ -0,0 +1,47 @@
+package lunarsolar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEquationOfTime(t *testing.T) {
+	for _, tc := range []struct {
+		scenario string
+		time     time.Time
+		expected time.Duration
+	}{
+		{
+			// Meeus, Astronomical Algorithms, example 28.b.
+			scenario: "meeus example",
+			time:     time.Date(1992, 10, 13, 0, 0, 0, 0, time.UTC).Add(-59 * time.Second),
+			expected: 13*time.Minute + 42700*time.Millisecond,
+		},
+		{
+			scenario: "february minimum",
+			time:     time.Date(2021, 2, 11, 12, 0, 0, 0, time.UTC),
+			expected: -(14*time.Minute + 14*time.Second),
+		},
+		{
+			scenario: "november maximum",
+			time:     time.Date(2021, 11, 3, 12, 0, 0, 0, time.UTC),
+			expected: 16*time.Minute + 28*time.Second,
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			assert.InDelta(t, tc.expected.Seconds(), EquationOfTime(tc.time).Seconds(), 3)
+		})
+	}
+}
+
+func TestTrueSolarTime(t *testing.T) {
+	// Noon in China Standard Time is 9:50 local mean time at Urumqi, 87.6°E,
+	// and the equation of time in early November adds about 16 minutes.
+	beijing := time.Date(2021, 11, 3, 12, 0, 0, 0, chinaStandardTime)
+	solar := TrueSolarTime(beijing, 87.6)
+	assert.True(t, beijing.Equal(solar))
+	assert.Equal(t, 10, solar.Hour())
+	assert.InDelta(t, 6, solar.Minute(), 1)
+}
@@ -0,0 +1,220 @@
+package lunarsolar
+
+import "fmt"
+
+const (
+	// MinYear is the first lunar year the package converts, the first year of
+	// the Ming dynasty. The years before those of the table follow
+	// ShixianCalendar and, before 1645, DatongCalendar.
+	MinYear = 1368
+	// MaxYear is the last lunar year covered by the calendar data.
+	MaxYear = 2100
+)
+
+// tableMinYear is the first lunar year covered by yearTable.
+const tableMinYear = 1900
+
+// yearTable holds one packed entry per lunar year from tableMinYear to
+// MaxYear.
+//
+//	bits 0-12   month lengths: bit i is set if the i-th month of the year,
+//	            counting a leap month in sequence, has 30 days instead of 29
+//	bits 13-16  the month that is followed by a leap month, or 0 if none
+//	bits 17-22  day of the solar year on which the lunar new year falls,
+//	            counting January 1 as 0
+//
+// The data agrees with the tables published by the Hong Kong Observatory,
+// including the years before 1929 that were reckoned at the Beijing
+// meridian rather than at UTC+8.
+var yearTable = [...]uint32{
+	// 1900-1909
+	0x3d16d2, 0x620752, 0x4c0ea5, 0x38b64a, 0x5c064b, 0x440a9b, 0x309556, 0x56056a, 0x400b59, 0x2a5752,
+	// 1910-1919
+	0x500752, 0x3adb25, 0x600b25, 0x480a4b, 0x32b4ab, 0x5802ad, 0x42056b, 0x2c4b69, 0x520da9, 0x3efd92,
+	// 1920-1929
+	0x640e92, 0x4c0d25, 0x36ba4d, 0x5c0a56, 0x4602b6, 0x2e95b5, 0x5606d4, 0x400ea9, 0x2c5e92, 0x500e92,
+	// 1930-1939
+	0x3acd26, 0x5e052b, 0x480a57, 0x32b2b6, 0x580b5a, 0x4406d4, 0x2e6ec9, 0x520749, 0x3cf693, 0x620a93,
+	// 1940-1949
+	0x4c052b, 0x34ca5b, 0x5a0aad, 0x46056a, 0x309b55, 0x560ba4, 0x400b49, 0x2a5a93, 0x500a95, 0x38f52d,
+	// 1950-1959
+	0x5e0536, 0x480aad, 0x34b5aa, 0x5805b2, 0x420da5, 0x2e7d4a, 0x540d4a, 0x3d0a95, 0x600a97, 0x4c0556,
+	// 1960-1969
+	0x36cab5, 0x5a0ad5, 0x4606d2, 0x308ea5, 0x560ea5, 0x40064a, 0x286c97, 0x4e0a9b, 0x3af55a, 0x5e056a,
+	// 1970-1979
+	0x480b69, 0x34b752, 0x5a0b52, 0x420b25, 0x2c964b, 0x520a4b, 0x3d14ab, 0x6002ad, 0x4a056d, 0x36cb69,
+	// 1980-1989
+	0x5c0da9, 0x460d92, 0x309d25, 0x560d25, 0x415a4d, 0x640a56, 0x4e02b6, 0x38c5b5, 0x5e06d5, 0x480ea9,
+	// 1990-1999
+	0x34be92, 0x5a0e92, 0x440d26, 0x2c6a56, 0x500a57, 0x3d14d6, 0x62035a, 0x4a06d5, 0x36b6c9, 0x5c0749,
+	// 2000-2009
+	0x460693, 0x2e952b, 0x54052b, 0x3e0a5b, 0x2a555a, 0x4e056a, 0x38fb55, 0x600ba4, 0x4a0b49, 0x32ba93,
+	// 2010-2019
+	0x580a95, 0x42052d, 0x2c8aad, 0x500ab5, 0x3d35aa, 0x6205d2, 0x4c0da5, 0x36dd4a, 0x5c0d4a, 0x460c95,
+	// 2020-2029
+	0x30952e, 0x540556, 0x3e0ab5, 0x2a55b2, 0x5006d2, 0x38cea5, 0x5e0725, 0x48064b, 0x32ac97, 0x560cab,
+	// 2030-2039
+	0x42055a, 0x2c6ad6, 0x520b69, 0x3d7752, 0x620b52, 0x4c0b25, 0x36da4b, 0x5a0a4b, 0x4404ab, 0x2ea55b,
+	// 2040-2049
+	0x5405ad, 0x3e0b6a, 0x2a5b52, 0x500d92, 0x3afd25, 0x5e0d25, 0x480a55, 0x32b4ad, 0x5804b6, 0x4005b5,
+	// 2050-2059
+	0x2c6daa, 0x520ec9, 0x3f1e92, 0x620e92, 0x4c0d26, 0x36ca56, 0x5a0a57, 0x4404d6, 0x2e86d5, 0x540755,
+	// 2060-2069
+	0x400749, 0x286e93, 0x4e0693, 0x38f52b, 0x5e052b, 0x460a5b, 0x32b55a, 0x58056a, 0x420b65, 0x2c974a,
+	// 2070-2079
+	0x520b4a, 0x3d1a95, 0x620a95, 0x4a052d, 0x34caad, 0x5a0ab5, 0x4605aa, 0x2e8ba5, 0x540da5, 0x400d4a,
+	// 2080-2089
+	0x2a7c95, 0x4e0c96, 0x38f94e, 0x5e0556, 0x480ab5, 0x32b5b2, 0x5806d2, 0x420ea5, 0x2e8e4a, 0x50064b,
+	// 2090-2099
+	0x3b0c97, 0x6004ab, 0x4a055b, 0x34cad6, 0x5a0b6a, 0x460752, 0x309725, 0x540b25, 0x3e0a8b, 0x28549b,
+	// 2100
+	0x4e04ab,
+}
+
+// DaysInMonth returns the number of days, 29 or 30, in the lunar month. If
+// isLeap is true it's the leap month that repeats month.
+func DaysInMonth(year, month int, isLeap bool) (int, error) {
+	if year < tableMinYear {
+		return historical(year).DaysInMonth(year, month, isLeap)
+	}
+	info, ok := yearInfo(year)
+	if !ok {
+		return 0, fmt.Errorf("%w: %d", ErrYearOutOfRange, year)
+	}
+	if month < 1 || month > 12 {
+		return 0, fmt.Errorf("%w: %d", ErrMonthOutOfRange, month)
+	}
+	if isLeap && month != infoLeapMonth(info) {
+		return 0, fmt.Errorf("%w: month %d of year %d", ErrNoSuchLeapMonth, month, year)
+	}
+	return infoMonthDays(info, infoMonthIndex(info, month, isLeap)), nil
+}
+
+// DaysInYear returns the number of days in the lunar year, from 353 to 355
+// for a common year and from 383 to 385 for a year with a leap month.
+func DaysInYear(year int) (int, error) {
+	if year < tableMinYear {
+		months, err := MonthsOf(year)
+		if err != nil {
+			return 0, err
+		}
+		n := 0
+		for _, m := range months {
+			n += m.Days
+		}
+		return n, nil
+	}
+	info, ok := yearInfo(year)
+	if !ok {
+		return 0, fmt.Errorf("%w: %d", ErrYearOutOfRange, year)
+	}
+	n := 0
+	for i := 0; i < infoMonthCount(info); i++ {
+		n += infoMonthDays(info, i)
+	}
+	return n, nil
+}
+
+// MonthsInYear returns the number of months in the lunar year, 13 if it has a
+// leap month and 12 otherwise.
+func MonthsInYear(year int) (int, error) {
+	if year < tableMinYear {
+		leapMonth, err := LeapMonth(year)
+		if err != nil {
+			return 0, err
+		}
+		if leapMonth != 0 {
+			return 13, nil
+		}
+		return 12, nil
+	}
+	info, ok := yearInfo(year)
+	if !ok {
+		return 0, fmt.Errorf("%w: %d", ErrYearOutOfRange, year)
+	}
+	return infoMonthCount(info), nil
+}
+
+// LeapMonth returns the month that is repeated as a leap month in the lunar
+// year, or 0 if the year has no leap month.
+func LeapMonth(year int) (int, error) {
+	if year < tableMinYear {
+		return historical(year).LeapMonth(year)
+	}
+	info, ok := yearInfo(year)
+	if !ok {
+		return 0, fmt.Errorf("%w: %d", ErrYearOutOfRange, year)
+	}
+	return infoLeapMonth(info), nil
+}
+
+// HasLeapMonth reports whether month is repeated as a leap month in the lunar
+// year.
+func HasLeapMonth(year, month int) (bool, error) {
+	if month < 1 || month > 12 {
+		return false, fmt.Errorf("%w: %d", ErrMonthOutOfRange, month)
+	}
+	leapMonth, err := LeapMonth(year)
+	if err != nil {
+		return false, err
+	}
+	return leapMonth == month, nil
+}
+
+// yearInfo returns the packed entry for the lunar year, and false if the year
+// is not covered by the table.
+func yearInfo(year int) (uint32, bool) {
+	if year < tableMinYear || year > MaxYear {
+		return 0, false
+	}
+	return yearTable[year-tableMinYear], true
+}
+
+// infoLeapMonth returns the month that is followed by a leap month, or 0.
+func infoLeapMonth(info uint32) int {
+	return int(info>>13) & 0xf
+}
+
+// infoNewYearOffset returns the number of days from January 1 to the lunar
+// new year.
+func infoNewYearOffset(info uint32) int {
+	return int(info>>17) & 0x3f
+}
+
+// infoMonthCount returns the number of months in the year, including a leap
+// month.
+func infoMonthCount(info uint32) int {
+	if infoLeapMonth(info) != 0 {
+		return 13
+	}
+	return 12
+}
+
+// infoMonthDays returns the length of the i-th month of the year, counting a
+// leap month in sequence and starting from 0.
+func infoMonthDays(info uint32, i int) int {
+	return 29 + int(info>>uint(i))&1
+}
+
+// infoMonthIndex returns the index of the month within the year, counting a
+// leap month in sequence and starting from 0.
+func infoMonthIndex(info uint32, month int, isLeap bool) int {
+	leapMonth := infoLeapMonth(info)
+	if leapMonth != 0 && (month > leapMonth || isLeap) {
+		return month
+	}
+	return month - 1
+}
+
+// infoMonthAt returns the month number and whether it's a leap month for the
+// month with the given index, as returned by infoMonthIndex.
+func infoMonthAt(info uint32, i int) (month int, isLeap bool) {
+	leapMonth := infoLeapMonth(info)
+	switch {
+	case leapMonth == 0 || i < leapMonth:
+		return i + 1, false
+	case i == leapMonth:
+		return i, true
+	}
+	return i, false
+}
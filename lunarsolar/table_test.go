@@ -0,0 +1,182 @@
+package lunarsolar
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDaysInMonth(t *testing.T) {
+	for _, tc := range []struct {
+		scenario    string
+		year        int
+		month       int
+		isLeap      bool
+		expected    int
+		expectedErr error
+	}{
+		{
+			scenario: "long month",
+			year:     2020,
+			month:    2,
+			expected: 30,
+		},
+		{
+			scenario: "short month",
+			year:     2020,
+			month:    1,
+			expected: 29,
+		},
+		{
+			scenario: "month after leap month",
+			year:     2020,
+			month:    5,
+			expected: 30,
+		},
+		{
+			scenario: "leap month",
+			year:     2020,
+			month:    4,
+			isLeap:   true,
+			expected: 29,
+		},
+		{
+			scenario:    "no such leap month",
+			year:        2019,
+			month:       4,
+			isLeap:      true,
+			expectedErr: ErrNoSuchLeapMonth,
+		},
+		{
+			scenario:    "month out of range",
+			year:        2019,
+			month:       13,
+			expectedErr: ErrMonthOutOfRange,
+		},
+		{
+			scenario:    "year out of range",
+			year:        MaxYear + 1,
+			month:       1,
+			expectedErr: ErrYearOutOfRange,
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			days, err := DaysInMonth(tc.year, tc.month, tc.isLeap)
+			if tc.expectedErr != nil {
+				assert.True(t, errors.Is(err, tc.expectedErr), err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, days)
+		})
+	}
+}
+
+func TestDaysInYear(t *testing.T) {
+	for _, tc := range []struct {
+		scenario       string
+		year           int
+		expectedDays   int
+		expectedMonths int
+	}{
+		{
+			scenario:       "common year",
+			year:           2019,
+			expectedDays:   354,
+			expectedMonths: 12,
+		},
+		{
+			scenario:       "leap year",
+			year:           2020,
+			expectedDays:   384,
+			expectedMonths: 13,
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			days, err := DaysInYear(tc.year)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedDays, days)
+
+			months, err := MonthsInYear(tc.year)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMonths, months)
+		})
+	}
+
+	_, err := DaysInYear(MinYear - 1)
+	assert.True(t, errors.Is(err, ErrYearOutOfRange), err)
+	_, err = MonthsInYear(MinYear - 1)
+	assert.True(t, errors.Is(err, ErrYearOutOfRange), err)
+}
+
+func TestLeapMonth(t *testing.T) {
+	for _, tc := range []struct {
+		scenario string
+		year     int
+		expected int
+	}{
+		{
+			scenario: "no leap month",
+			year:     2019,
+			expected: 0,
+		},
+		{
+			scenario: "leap 4th month",
+			year:     2020,
+			expected: 4,
+		},
+		{
+			scenario: "leap 5th month",
+			year:     1998,
+			expected: 5,
+		},
+		{
+			scenario: "leap 11th month",
+			year:     2033,
+			expected: 11,
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			leapMonth, err := LeapMonth(tc.year)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, leapMonth)
+
+			for month := 1; month <= 12; month++ {
+				hasLeap, err := HasLeapMonth(tc.year, month)
+				require.NoError(t, err)
+				assert.Equal(t, month == tc.expected, hasLeap, month)
+			}
+		})
+	}
+
+	_, err := LeapMonth(MaxYear + 1)
+	assert.True(t, errors.Is(err, ErrYearOutOfRange), err)
+	_, err = HasLeapMonth(2020, 0)
+	assert.True(t, errors.Is(err, ErrMonthOutOfRange), err)
+}
+
+// The table must describe years of possible lengths that follow on from one
+// another without gaps.
+func TestYearTableConsistent(t *testing.T) {
+	for year := tableMinYear; year <= MaxYear; year++ {
+		days, err := DaysInYear(year)
+		require.NoError(t, err)
+		months, err := MonthsInYear(year)
+		require.NoError(t, err)
+		if months == 12 {
+			assert.True(t, days >= 353 && days <= 355, "%d has %d days", year, days)
+		} else {
+			assert.True(t, days >= 383 && days <= 385, "%d has %d days", year, days)
+		}
+
+		if year == MaxYear {
+			continue
+		}
+		info, _ := yearInfo(year)
+		next, _ := yearInfo(year + 1)
+		end := newYearJDN(year, info) + days
+		assert.Equal(t, newYearJDN(year+1, next), end, year)
+	}
+}
@@ -0,0 +1,79 @@
+package lunarsolar
+
+import (
+	"strconv"
+	"time"
+)
+
+var vietnamese = NewAstronomicalCalendar(7 * time.Hour)
+
+// VietnameseCalendar returns the Vietnamese lunisolar calendar (âm lịch). It
+// follows the same rules as the Chinese calendar, but reckons days at UTC+7,
+// so it differs whenever a new moon or major solar term falls between
+// midnight in Vietnam and midnight in China. Tết of 2007 was on February 17,
+// a day before Chinese New Year, and in 1985 it came a month earlier, on
+// January 21, as the leap month of 1984-85 fell in a different place.
+//
+// The calendar uses UTC+7 for every year, including those before 1968 in
+// which the North reckoned the calendar at UTC+8.
+func VietnameseCalendar() *AstronomicalCalendar {
+	return vietnamese
+}
+
+var (
+	stemVietnamese   = [...]string{"Giáp", "Ất", "Bính", "Đinh", "Mậu", "Kỷ", "Canh", "Tân", "Nhâm", "Quý"}
+	branchVietnamese = [...]string{"Tý", "Sửu", "Dần", "Mão", "Thìn", "Tỵ", "Ngọ", "Mùi", "Thân", "Dậu", "Tuất", "Hợi"}
+)
+
+// Vietnamese returns the stem in Vietnamese, e.g. "Canh".
+func (s Stem) Vietnamese() string {
+	return stemVietnamese[mod(int(s), 10)]
+}
+
+// Vietnamese returns the branch in Vietnamese, e.g. "Tý".
+func (b Branch) Vietnamese() string {
+	return branchVietnamese[mod(int(b), 12)]
+}
+
+// Vietnamese returns the stem and branch in Vietnamese, e.g. "Canh Tý".
+func (g GanZhi) Vietnamese() string {
+	return g.Stem().Vietnamese() + " " + g.Branch().Vietnamese()
+}
+
+// Vietnamese returns the name of the animal in the Vietnamese zodiac, e.g.
+// "Chuột". The Vietnamese zodiac has the cat (Mèo) in place of the rabbit,
+// and the water buffalo (Trâu) for the ox.
+func (a Animal) Vietnamese() string {
+	return vietnameseScript.animals[mod(int(a), 12)]
+}
+
+// LayoutVietnamese writes a date in Vietnamese, e.g. "mùng 1 tháng Tư nhuận
+// năm Canh Tý" for the 1st day of the leap 4th month of 2020.
+const LayoutVietnamese = "初二 正月闰 năm 丙戌"
+
+var vietnameseScript = script{
+	leap: " nhuận",
+	months: [12]string{"tháng Giêng", "tháng Hai", "tháng Ba", "tháng Tư", "tháng Năm", "tháng Sáu",
+		"tháng Bảy", "tháng Tám", "tháng Chín", "tháng Mười", "tháng Mười Một", "tháng Chạp"},
+	animals: [12]string{"Chuột", "Trâu", "Hổ", "Mèo", "Rồng", "Rắn", "Ngựa", "Dê", "Khỉ", "Gà", "Chó", "Lợn"},
+	year:    strconv.Itoa,
+	ganZhi:  GanZhi.Vietnamese,
+	day:     vietnameseDay,
+}
+
+// FormatVietnamese is like Format but writes the named elements in
+// Vietnamese: "正月" as "tháng Giêng", "初二" as "mùng 2", "闰" as " nhuận", "丙戌"
+// as "Bính Tuất", "狗" as the animal of the Vietnamese zodiac and "二〇〇六" in
+// digits.
+func (t LunarTime) FormatVietnamese(layout string) string {
+	return t.format(layout, &vietnameseScript)
+}
+
+// vietnameseDay returns "mùng 1" to "mùng 10" for the first ten days of the
+// month, and the number of the day after that.
+func vietnameseDay(day int) string {
+	if day >= 1 && day <= 10 {
+		return "mùng " + strconv.Itoa(day)
+	}
+	return strconv.Itoa(day)
+}
@@ -0,0 +1,71 @@
+package lunarsolar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVietnameseCalendar(t *testing.T) {
+	v := VietnameseCalendar()
+	for _, tc := range []struct {
+		scenario string
+		solar    time.Time
+		expected LunarDate
+		chinese  LunarDate
+	}{
+		{
+			scenario: "tet a day before chinese new year",
+			solar:    time.Date(2007, 2, 17, 0, 0, 0, 0, time.UTC),
+			expected: NewLunarDate(2007, 1, 1, false),
+			chinese:  NewLunarDate(2006, 12, 30, false),
+		},
+		{
+			scenario: "tet a month before chinese new year",
+			solar:    time.Date(1985, 1, 21, 0, 0, 0, 0, time.UTC),
+			expected: NewLunarDate(1985, 1, 1, false),
+			chinese:  NewLunarDate(1984, 12, 1, false),
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			lunar, err := v.ToLunar(tc.solar)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, lunar.Date())
+
+			chinese, err := SolarToLunar(tc.solar)
+			require.NoError(t, err)
+			assert.Equal(t, tc.chinese, chinese.Date())
+		})
+	}
+}
+
+func TestFormatVietnamese(t *testing.T) {
+	for _, tc := range []struct {
+		date     LunarDate
+		layout   string
+		expected string
+	}{
+		{
+			date:     NewLunarDate(2020, 4, 1, true),
+			layout:   LayoutVietnamese,
+			expected: "mùng 1 tháng Tư nhuận năm Canh Tý",
+		},
+		{
+			date:     NewLunarDate(2023, 12, 15, false),
+			layout:   LayoutVietnamese + " (狗)",
+			expected: "15 tháng Chạp năm Quý Mão (Mèo)",
+		},
+		{
+			date:     NewLunarDate(2006, 1, 2, false),
+			layout:   "ngày 初二/1/二〇〇六",
+			expected: "ngày mùng 2/1/2006",
+		},
+	} {
+		t.Run(tc.expected, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.date.At(0, 0, 0, 0, time.UTC).FormatVietnamese(tc.layout))
+		})
+	}
+	assert.Equal(t, "Mèo", Rabbit.Vietnamese())
+}
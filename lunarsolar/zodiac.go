@@ -0,0 +1,201 @@
+package lunarsolar
+
+import (
+	"fmt"
+	"time"
+)
+
+// Animal is one of the twelve animals of the Chinese zodiac, in the order of
+// the earthly branches.
+type Animal int
+
+const (
+	Rat Animal = iota
+	Ox
+	Tiger
+	Rabbit
+	Dragon
+	Snake
+	Horse
+	Goat
+	Monkey
+	Rooster
+	Dog
+	Pig
+)
+
+var animalNames = [...]struct{ english, chinese string }{
+	{"Rat", "鼠"},
+	{"Ox", "牛"},
+	{"Tiger", "虎"},
+	{"Rabbit", "兔"},
+	{"Dragon", "龙"},
+	{"Snake", "蛇"},
+	{"Horse", "马"},
+	{"Goat", "羊"},
+	{"Monkey", "猴"},
+	{"Rooster", "鸡"},
+	{"Dog", "狗"},
+	{"Pig", "猪"},
+}
+
+// String returns the English name of the animal, e.g. "Rat".
+func (a Animal) String() string {
+	return animalNames[mod(int(a), 12)].english
+}
+
+// Chinese returns the name of the animal in Chinese, e.g. "鼠".
+func (a Animal) Chinese() string {
+	return animalNames[mod(int(a), 12)].chinese
+}
+
+// Element is one of the five elements (wuxing), in the order in which they
+// generate each other.
+type Element int
+
+const (
+	Wood Element = iota
+	Fire
+	Earth
+	Metal
+	Water
+)
+
+var elementNames = [...]struct{ english, chinese string }{
+	{"Wood", "木"},
+	{"Fire", "火"},
+	{"Earth", "土"},
+	{"Metal", "金"},
+	{"Water", "水"},
+}
+
+// String returns the English name of the element, e.g. "Wood".
+func (e Element) String() string {
+	return elementNames[mod(int(e), 5)].english
+}
+
+// Chinese returns the name of the element in Chinese, e.g. "木".
+func (e Element) Chinese() string {
+	return elementNames[mod(int(e), 5)].chinese
+}
+
+// Element returns the element of the stem. Each element has two stems in a
+// row, Jia and Yi being Wood.
+func (s Stem) Element() Element {
+	return Element(mod(int(s), 10) / 2)
+}
+
+var branchElements = [...]Element{Water, Earth, Wood, Wood, Earth, Fire, Fire, Earth, Metal, Metal, Earth, Water}
+
+// Element returns the element of the branch.
+func (b Branch) Element() Element {
+	return branchElements[mod(int(b), 12)]
+}
+
+// Animal returns the zodiac animal of the branch.
+func (b Branch) Animal() Animal {
+	return Animal(mod(int(b), 12))
+}
+
+// Nayin is one of the 30 nayin (纳音) sounds, each shared by two consecutive
+// stem-branch pairs of the sexagenary cycle, from Haizhongjin (海中金) for
+// Jiazi and Yichou.
+type Nayin int
+
+var nayinNames = [...]struct {
+	chinese string
+	element Element
+}{
+	{"海中金", Metal}, {"炉中火", Fire}, {"大林木", Wood}, {"路旁土", Earth}, {"剑锋金", Metal},
+	{"山头火", Fire}, {"涧下水", Water}, {"城头土", Earth}, {"白蜡金", Metal}, {"杨柳木", Wood},
+	{"泉中水", Water}, {"屋上土", Earth}, {"霹雳火", Fire}, {"松柏木", Wood}, {"长流水", Water},
+	{"砂中金", Metal}, {"山下火", Fire}, {"平地木", Wood}, {"壁上土", Earth}, {"金箔金", Metal},
+	{"覆灯火", Fire}, {"天河水", Water}, {"大驿土", Earth}, {"钗钏金", Metal}, {"桑柘木", Wood},
+	{"大溪水", Water}, {"沙中土", Earth}, {"天上火", Fire}, {"石榴木", Wood}, {"大海水", Water},
+}
+
+// String returns the nayin in Chinese, e.g. "海中金".
+func (n Nayin) String() string {
+	return nayinNames[mod(int(n), 30)].chinese
+}
+
+// Element returns the element of the nayin.
+func (n Nayin) Element() Element {
+	return nayinNames[mod(int(n), 30)].element
+}
+
+// Nayin returns the nayin of the stem-branch.
+func (g GanZhi) Nayin() Nayin {
+	return Nayin(mod(int(g), 60) / 2)
+}
+
+// YearBoundary selects when a new zodiac year begins.
+type YearBoundary int
+
+const (
+	// BoundaryNewYear begins the zodiac year on the first day of the lunar
+	// year, Chinese New Year.
+	BoundaryNewYear YearBoundary = iota
+	// BoundaryLichun begins the zodiac year at the instant of Lichun, the
+	// start of spring, as in Chinese astrology.
+	BoundaryLichun
+)
+
+// Zodiac holds the attributes of a zodiac year.
+type Zodiac struct {
+	// Year is the lunar year, or with BoundaryLichun the Gregorian year in
+	// which the zodiac year began.
+	Year   int
+	GanZhi GanZhi
+	Animal Animal
+	// Element is the element of the year stem.
+	Element Element
+	Nayin   Nayin
+}
+
+// NewZodiac returns the attributes of the year.
+func NewZodiac(year int) Zodiac {
+	g := YearGanZhi(year)
+	return Zodiac{
+		Year:    year,
+		GanZhi:  g,
+		Animal:  g.Branch().Animal(),
+		Element: g.Stem().Element(),
+		Nayin:   g.Nayin(),
+	}
+}
+
+// ZodiacOf returns the zodiac year of t. With BoundaryNewYear only the year,
+// month and day of t are used, as in SolarToLunar, and an error is returned
+// if the date is out of range. With BoundaryLichun t is compared as an
+// instant with Lichun, and the year is that of t in the zone in which the
+// calendar was reckoned: China Standard Time, UTC+8, or Beijing mean time
+// before 1929.
+func ZodiacOf(t time.Time, boundary YearBoundary) (Zodiac, error) {
+	switch boundary {
+	case BoundaryNewYear:
+		lunar, err := SolarToLunar(t)
+		if err != nil {
+			return Zodiac{}, err
+		}
+		return lunar.Zodiac(), nil
+	case BoundaryLichun:
+		year := t.In(chinaZone(t)).Year()
+		if t.Before(lichun(year)) {
+			year--
+		}
+		return NewZodiac(year), nil
+	}
+	return Zodiac{}, fmt.Errorf("lunarsolar: unknown year boundary %d", boundary)
+}
+
+// Zodiac returns the zodiac attributes of the lunar year of t.
+func (t LunarTime) Zodiac() Zodiac {
+	return NewZodiac(t.date.Year)
+}
+
+// lichun returns the instant of Lichun in the Gregorian year.
+func lichun(year int) time.Time {
+	feb4 := float64(civilToJDN(year, time.February, 4))
+	return solarTermTime(sunLongitudeJDE(Lichun.Longitude(), feb4))
+}
@@ -0,0 +1,92 @@
+package lunarsolar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewZodiac(t *testing.T) {
+	z := NewZodiac(2020)
+	assert.Equal(t, Rat, z.Animal)
+	assert.Equal(t, "鼠", z.Animal.Chinese())
+	assert.Equal(t, Metal, z.Element)
+	assert.Equal(t, "壁上土", z.Nayin.String())
+	assert.Equal(t, Earth, z.Nayin.Element())
+
+	z = NewZodiac(1984)
+	assert.Equal(t, Rat, z.Animal)
+	assert.Equal(t, Wood, z.Element)
+	assert.Equal(t, "海中金", z.Nayin.String())
+
+	z = NewZodiac(2021)
+	assert.Equal(t, "Ox", z.Animal.String())
+	assert.Equal(t, "金", z.Element.Chinese())
+	assert.Equal(t, "壁上土", z.Nayin.String())
+
+	assert.Equal(t, "大海水", GanZhi(59).Nayin().String())
+	assert.Equal(t, Water, BranchZi.Element())
+	assert.Equal(t, Fire, StemDing.Element())
+}
+
+func TestZodiacOf(t *testing.T) {
+	for _, tc := range []struct {
+		scenario string
+		time     time.Time
+		boundary YearBoundary
+		expected Animal
+	}{
+		{
+			scenario: "after lichun before new year",
+			time:     time.Date(2023, 1, 10, 12, 0, 0, 0, chinaStandardTime),
+			boundary: BoundaryNewYear,
+			expected: Tiger,
+		},
+		{
+			scenario: "after new year before lichun",
+			time:     time.Date(2023, 1, 25, 12, 0, 0, 0, chinaStandardTime),
+			boundary: BoundaryNewYear,
+			expected: Rabbit,
+		},
+		{
+			scenario: "after new year before lichun by lichun",
+			time:     time.Date(2023, 1, 25, 12, 0, 0, 0, chinaStandardTime),
+			boundary: BoundaryLichun,
+			expected: Tiger,
+		},
+		{
+			scenario: "minute before lichun",
+			time:     time.Date(2021, 2, 3, 22, 58, 0, 0, chinaStandardTime),
+			boundary: BoundaryLichun,
+			expected: Rat,
+		},
+		{
+			scenario: "minute after lichun",
+			time:     time.Date(2021, 2, 3, 23, 0, 0, 0, chinaStandardTime),
+			boundary: BoundaryLichun,
+			expected: Ox,
+		},
+		{
+			scenario: "before lichun after new year",
+			time:     time.Date(2020, 1, 30, 0, 0, 0, 0, time.UTC),
+			boundary: BoundaryLichun,
+			expected: Pig,
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			z, err := ZodiacOf(tc.time, tc.boundary)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, z.Animal)
+		})
+	}
+
+	z, err := ZodiacOf(time.Date(1850, 1, 1, 0, 0, 0, 0, time.UTC), BoundaryNewYear)
+	require.NoError(t, err)
+	assert.Equal(t, Rooster, z.Animal)
+	_, err = ZodiacOf(time.Date(1367, 6, 1, 0, 0, 0, 0, time.UTC), BoundaryNewYear)
+	assert.Error(t, err)
+	_, err = ZodiacOf(time.Now(), YearBoundary(5))
+	assert.Error(t, err)
+}